@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"github.com/OwodDEV/crypto-service/internal/config"
@@ -73,11 +74,55 @@ func runApp(ctx context.Context, cfg *config.Config) error {
 	}
 	defer external.Tron.Shutdown()
 
-	err = storages.Cache.Connect()
-	if err != nil {
-		return err
+	if cfg.Storages.Cache.Enabled {
+		err = storages.Cache.Connect()
+		if err != nil {
+			return err
+		}
+		defer storages.Cache.Shutdown()
+	}
+
+	// background jobs are stopped and awaited before the connections above are closed
+	var jobs sync.WaitGroup
+	defer jobs.Wait()
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	runJob := func(job func(ctx context.Context)) {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			job(jobsCtx)
+		}()
+	}
+
+	if cfg.Service.Screening.Enabled {
+		runJob(srv.RunScreeningReloader)
+	}
+
+	// the state of these jobs is kept in Redis only
+	if cfg.Storages.Cache.Enabled {
+		runJob(storages.Cache.RunHealthCheck)
+		if cfg.Storages.Memory.Enabled {
+			runJob(storages.Memory.ListenInvalidations)
+		}
+		// the block follower and the poller only feed the balance invalidator
+		if cfg.Service.BalanceCache.InvalidateOnTransfer {
+			runJob(external.Ethereum.FollowBlocks)
+			runJob(external.Tron.PollBlocks)
+			runJob(srv.RunBalanceInvalidator)
+		}
+		runJob(srv.RunTransactionTracker)
+		runJob(srv.RunTransferWatcher)
+		runJob(srv.RunInvoiceExpirer)
+		runJob(srv.RunWebhookDispatcher)
+		runJob(srv.RunWatchlistPoller)
+		if cfg.Service.Sweeper.Enabled {
+			runJob(srv.RunSweeper)
+		}
+		if cfg.Service.Alerts.Enabled {
+			runJob(srv.RunBalanceAlerts)
+		}
 	}
-	defer storages.Cache.Shutdown()
 
 	go httpServer.Run(errCh)
 	defer httpServer.Shutdown()
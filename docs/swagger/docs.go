@@ -15,6 +15,232 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/invoices": {
+            "post": {
+                "description": "Create a payment invoice with an assigned receiving address",
+                "tags": [
+                    "invoices"
+                ],
+                "parameters": [
+                    {
+                        "description": "Invoice. ` + "`" + `network` + "`" + ` is ethereum or tron, ` + "`" + `expires_in` + "`" + ` is in seconds",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateInvoiceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invoices/{id}": {
+            "get": {
+                "description": "Get a payment invoice and its payments",
+                "tags": [
+                    "invoices"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/screening/addresses": {
+            "get": {
+                "description": "List the internal screening list. Entries loaded from the list files are not included",
+                "tags": [
+                    "screening"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ScreeningEntry"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an address to the internal screening list. Requires the admin token",
+                "tags": [
+                    "screening"
+                ],
+                "parameters": [
+                    {
+                        "description": "Blocked address. ` + "`" + `network` + "`" + ` is ethereum or tron, detected by the address when empty",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateScreeningEntryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScreeningEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/screening/addresses/{network}/{address}": {
+            "delete": {
+                "description": "Remove an address from the internal screening list. Requires the admin token",
+                "tags": [
+                    "screening"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "ethereum",
+                            "tron"
+                        ],
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wallet Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stream": {
+            "get": {
+                "description": "WebSocket stream of balance changes and token transfers.\nSend {\"action\": \"subscribe\"|\"unsubscribe\", \"addresses\": [...]} to manage subscriptions.\nThe server pushes models.StreamMessage objects of type \"balance\", \"transfer\", \"subscribed\", \"unsubscribed\" and \"error\",\nand sends ping frames every heartbeat interval. Clients that do not answer pings are disconnected.",
+                "tags": [
+                    "stream"
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/models.StreamMessage"
+                        }
+                    },
+                    "426": {
+                        "description": "Upgrade Required"
+                    }
+                }
+            }
+        },
         "/api/transaction/{hash}": {
             "get": {
                 "description": "Get USDT transaction details",
@@ -29,6 +255,24 @@ const docTemplate = `{
                         "name": "hash",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "no-cache for a live value, max-age=\u003cseconds\u003e for a value read at most that long ago",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Same as Cache-Control: no-cache",
+                        "name": "no_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Same as Cache-Control: max-age, in seconds",
+                        "name": "max_age",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -36,13 +280,158 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.GetTransactionResp"
+                        },
+                        "headers": {
+                            "X-Block-Number": {
+                                "type": "integer",
+                                "description": "Latest block known when the value was read"
+                            },
+                            "X-Cached-At": {
+                                "type": "string",
+                                "description": "When the value was read from the node"
+                            },
+                            "X-Data-Source": {
+                                "type": "string",
+                                "description": "cache or live"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/lookup": {
+            "post": {
+                "description": "Look up USDT transactions of several hashes across networks. The network of each hash is detected and the transactions are fetched concurrently. Failed hashes carry an error instead of the transaction.",
+                "tags": [
+                    "transaction"
+                ],
+                "parameters": [
+                    {
+                        "description": "Transaction hashes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LookupTransactionsReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "no-cache for live values, max-age=\u003cseconds\u003e for values read at most that long ago",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Same as Cache-Control: no-cache",
+                        "name": "no_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Same as Cache-Control: max-age, in seconds",
+                        "name": "max_age",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LookupTransactionsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tron/account/{address}/resources": {
+            "get": {
+                "description": "Get Tron account resources: bandwidth, energy, Stake 2.0 staked TRX, delegated resources and activation state",
+                "tags": [
+                    "tron"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "TLSrrT5DiF5TkWPffJVQNwKE7SrctRCcpD",
+                        "description": "Tron Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetTronAccountResourcesResp"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
                     }
                 }
             }
@@ -61,6 +450,24 @@ const docTemplate = `{
                         "name": "address",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "no-cache for a live value, max-age=\u003cseconds\u003e for a value read at most that long ago",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Same as Cache-Control: no-cache",
+                        "name": "no_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Same as Cache-Control: max-age, in seconds",
+                        "name": "max_age",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -68,38 +475,1610 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.GetWalletResp"
+                        },
+                        "headers": {
+                            "X-Block-Number": {
+                                "type": "integer",
+                                "description": "Block the balance was read at, omitted when unknown"
+                            },
+                            "X-Cached-At": {
+                                "type": "string",
+                                "description": "When the value was read from the node"
+                            },
+                            "X-Data-Source": {
+                                "type": "string",
+                                "description": "cache or live"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.GetTransactionResp": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "string"
-                },
-                "from": {
-                    "type": "string"
-                },
-                "to": {
-                    "type": "string"
+        },
+        "/api/wallets/balances": {
+            "post": {
+                "description": "Get USDT balances of several addresses. Cached balances are served in one batch, the rest is read from the nodes concurrently. Failed items carry an error instead of the balance.",
+                "tags": [
+                    "wallet"
+                ],
+                "parameters": [
+                    {
+                        "description": "Balances to look up. ` + "`" + `network` + "`" + ` is ethereum or tron, detected from the address when omitted",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GetWalletBalancesReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "no-cache for live values, max-age=\u003cseconds\u003e for values read at most that long ago",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Same as Cache-Control: no-cache",
+                        "name": "no_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Same as Cache-Control: max-age, in seconds",
+                        "name": "max_age",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetWalletBalancesResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/watchlist": {
+            "get": {
+                "description": "List watched addresses",
+                "tags": [
+                    "watchlist"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WatchedAddress"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an address to the watchlist",
+                "tags": [
+                    "watchlist"
+                ],
+                "parameters": [
+                    {
+                        "description": "Watched address. ` + "`" + `network` + "`" + ` is ethereum or tron",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateWatchedAddressReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchedAddress"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/watchlist/{network}/{address}": {
+            "get": {
+                "description": "Get a watched address with its last known balances",
+                "tags": [
+                    "watchlist"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "ethereum",
+                            "tron"
+                        ],
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wallet Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchedAddress"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the tokens, label and owner reference of a watched address",
+                "tags": [
+                    "watchlist"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "ethereum",
+                            "tron"
+                        ],
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wallet Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Watched address",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateWatchedAddressReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchedAddress"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an address from the watchlist",
+                "tags": [
+                    "watchlist"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "ethereum",
+                            "tron"
+                        ],
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wallet Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/watchlist/{network}/{address}/balance-changes": {
+            "get": {
+                "description": "Get the recorded balance changes of a watched address, newest first",
+                "tags": [
+                    "watchlist"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "ethereum",
+                            "tron"
+                        ],
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wallet Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BalanceChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "post": {
+                "description": "Subscribe a URL to transfer events of the given addresses.\nEvery event is POSTed as JSON with the headers X-Webhook-Event-ID, X-Webhook-Timestamp\nand X-Webhook-Signature: \"sha256=\" + hex HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" keyed with the returned secret.\nFailed deliveries are retried with exponential backoff.",
+                "tags": [
+                    "webhooks"
+                ],
+                "parameters": [
+                    {
+                        "description": "Subscription",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateWebhookSubscriptionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}": {
+            "get": {
+                "description": "Get a webhook subscription",
+                "tags": [
+                    "webhooks"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a webhook subscription",
+                "tags": [
+                    "webhooks"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Get the delivery log of a webhook subscription, newest first",
+                "tags": [
+                    "webhooks"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/{network}/tracked-transactions": {
+            "post": {
+                "description": "Register an outgoing transaction for lifecycle tracking",
+                "tags": [
+                    "tracked-transactions"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "ethereum",
+                            "tron"
+                        ],
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction to track. ` + "`" + `replaces` + "`" + ` links a speed-up or cancel transaction to the original one",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TrackTransactionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TrackedTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/{network}/tracked-transactions/{hash}": {
+            "get": {
+                "description": "Get the state and state history of a tracked transaction",
+                "tags": [
+                    "tracked-transactions"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "ethereum",
+                            "tron"
+                        ],
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction Hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TrackedTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/{network}/wallet/{address}/blacklist": {
+            "get": {
+                "description": "Check whether the USDT issuer froze (blacklisted) the address",
+                "tags": [
+                    "wallet"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "ethereum",
+                            "tron"
+                        ],
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\u003cbr\u003eERC20 USDT: \"0xe983fD1798689eee00c0Fb77e79B8f372DF41060\", \u003cbr\u003eTRC20 USDT: \"TLSrrT5DiF5TkWPffJVQNwKE7SrctRCcpD\"",
+                        "description": "Wallet Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetBlacklistStatusResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Get the service health. The status is \"degraded\" while Redis is unavailable and requests bypass the cache",
+                "tags": [
+                    "health"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResp"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.BalanceChange": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "string"
+                },
+                "previous": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ComponentStatus": {
+            "type": "object",
+            "properties": {
+                "last_error": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateInvoiceReq": {
+            "type": "object",
+            "required": [
+                "amount",
+                "network",
+                "token"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "minimum": 60
+                },
+                "network": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string",
+                    "enum": [
+                        "USDT"
+                    ]
+                }
+            }
+        },
+        "models.CreateScreeningEntryReq": {
+            "type": "object",
+            "required": [
+                "address"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateWatchedAddressReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "network",
+                "tokens"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "owner_ref": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CreateWebhookSubscriptionReq": {
+            "type": "object",
+            "required": [
+                "addresses",
+                "url"
+            ],
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ErrorResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "watched_address_not_found"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "watched address not found"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GetBlacklistStatusResp": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "frozen": {
+                    "type": "boolean"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GetTransactionResp": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "cached_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "screening": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScreeningMatch"
+                    }
+                },
+                "screening_incomplete": {
+                    "description": "ScreeningIncomplete is set when only the screening list files could be checked",
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "cache",
+                        "live"
+                    ]
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GetTronAccountResourcesResp": {
+            "type": "object",
+            "properties": {
+                "activated": {
+                    "type": "boolean"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string"
+                },
+                "bandwidth": {
+                    "$ref": "#/definitions/models.TronBandwidth"
+                },
+                "delegated_in": {
+                    "$ref": "#/definitions/models.TronResourceAmounts"
+                },
+                "delegated_out": {
+                    "$ref": "#/definitions/models.TronResourceAmounts"
+                },
+                "delegations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TronResourceDelegation"
+                    }
+                },
+                "energy": {
+                    "$ref": "#/definitions/models.TronEnergy"
+                },
+                "staked": {
+                    "$ref": "#/definitions/models.TronResourceAmounts"
+                },
+                "unstaking": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TronUnstake"
+                    }
+                }
+            }
+        },
+        "models.GetWalletBalancesReq": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.WalletBalanceItem"
+                    }
+                }
+            }
+        },
+        "models.GetWalletBalancesResp": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WalletBalanceResult"
+                    }
+                }
+            }
+        },
+        "models.GetWalletResp": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "cached_at": {
+                    "type": "string"
+                },
+                "frozen": {
+                    "type": "boolean"
+                },
+                "screening": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScreeningMatch"
+                    }
+                },
+                "screening_incomplete": {
+                    "description": "ScreeningIncomplete is set when only the screening list files could be checked",
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "cache",
+                        "live"
+                    ]
+                },
+                "stale": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.HealthResp": {
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "$ref": "#/definitions/models.ComponentStatus"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Invoice": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvoicePayment"
+                    }
+                },
+                "received": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InvoicePayment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "log_index": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ItemError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_address"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "unknown address format"
+                }
+            }
+        },
+        "models.LookupTransactionsReq": {
+            "type": "object",
+            "required": [
+                "hashes"
+            ],
+            "properties": {
+                "hashes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.LookupTransactionsResp": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TransactionLookupResult"
+                    }
+                }
+            }
+        },
+        "models.ScreeningEntry": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ScreeningMatch": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "party": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
                 }
             }
         },
-        "models.GetWalletResp": {
+        "models.StreamMessage": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "balance": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "transfer": {
+                    "$ref": "#/definitions/models.TransferEvent"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TrackTransactionReq": {
+            "type": "object",
+            "required": [
+                "hash"
+            ],
+            "properties": {
+                "hash": {
+                    "type": "string"
+                },
+                "replaces": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TrackedTransaction": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer"
+                },
+                "confirmations": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "final": {
+                    "type": "boolean"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TrackedTransactionEvent"
+                    }
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "integer"
+                },
+                "replaced_by": {
+                    "type": "string"
+                },
+                "replaces": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TrackedTransactionEvent": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TransactionLookupResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/models.ItemError"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/models.GetTransactionResp"
+                }
+            }
+        },
+        "models.TransferEvent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "block_time": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "log_index": {
+                    "type": "integer"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TronBandwidth": {
+            "type": "object",
+            "properties": {
+                "free_limit": {
+                    "type": "integer"
+                },
+                "free_used": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TronEnergy": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TronResourceAmounts": {
+            "type": "object",
+            "properties": {
+                "bandwidth": {
+                    "type": "string"
+                },
+                "energy": {
+                    "type": "string"
+                },
+                "tron_power": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TronResourceDelegation": {
+            "type": "object",
+            "properties": {
+                "bandwidth": {
+                    "type": "string"
+                },
+                "bandwidth_expire_time": {
+                    "type": "integer"
+                },
+                "energy": {
+                    "type": "string"
+                },
+                "energy_expire_time": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TronUnstake": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "expire_time": {
+                    "type": "integer"
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdateWatchedAddressReq": {
+            "type": "object",
+            "required": [
+                "tokens"
+            ],
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "owner_ref": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.WalletBalanceItem": {
+            "type": "object",
+            "required": [
+                "address"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "0xe983fD1798689eee00c0Fb77e79B8f372DF41060"
+                },
+                "network": {
+                    "type": "string",
+                    "example": "ethereum"
+                },
+                "token": {
+                    "type": "string",
+                    "enum": [
+                        "USDT"
+                    ],
+                    "example": "USDT"
+                }
+            }
+        },
+        "models.WalletBalanceResult": {
             "type": "object",
             "properties": {
+                "address": {
+                    "type": "string"
+                },
                 "balance": {
                     "type": "string"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "cached_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "$ref": "#/definitions/models.ItemError"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "cache",
+                        "live"
+                    ]
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WatchedAddress": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "balances": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "owner_ref": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/models.WebhookEvent"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookEvent": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "$ref": "#/definitions/models.TransferEvent"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         }
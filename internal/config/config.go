@@ -20,29 +20,197 @@ type Config struct {
 
 	Transport struct {
 		HTTP struct {
-			Host string `yaml:"host"`
-			Port string `yaml:"port"`
+			Host       string `yaml:"host"`
+			Port       string `yaml:"port"`
+			AdminToken string `env:"CRYPTOSERVICE_ADMIN_TOKEN"`
 		} `yaml:"http"`
+		WebSocket struct {
+			MaxSubscriptions  int   `yaml:"max_subscriptions"`
+			HeartbeatInterval int64 `yaml:"heartbeat_interval"`
+			SendBufferSize    int   `yaml:"send_buffer_size"`
+		} `yaml:"websocket"`
 	} `yaml:"transport"`
 
 	External struct {
 		Ethereum struct {
-			RPCEndpoint string `env:"CRYPTOSERVICE_ETHEREUM_RPCENDPOINT"`
+			RPCEndpoint   string `env:"CRYPTOSERVICE_ETHEREUM_RPCENDPOINT"`
+			Confirmations uint64 `yaml:"confirmations"`
+			Follower      struct {
+				PollInterval int64 `yaml:"poll_interval"`
+				Depth        int   `yaml:"depth"`
+				BufferSize   int   `yaml:"buffer_size"`
+			} `yaml:"follower"`
 		} `yaml:"Ethereum"`
 		Tron struct {
-			RPCEndpoint string `env:"CRYPTOSERVICE_TRON_RPCENDPOINT"`
+			RPCEndpoint   string `env:"CRYPTOSERVICE_TRON_RPCENDPOINT"`
+			Confirmations uint64 `yaml:"confirmations"`
+			FeeLimit      int64  `yaml:"fee_limit"`
+			Poller        struct {
+				PollInterval int64 `yaml:"poll_interval"`
+				MaxBlocks    int64 `yaml:"max_blocks"`
+				BufferSize   int   `yaml:"buffer_size"`
+			} `yaml:"poller"`
 		} `yaml:"Tron"`
+		Webhook struct {
+			Timeout      int64    `yaml:"timeout"`
+			AllowedHosts []string `yaml:"allowed_hosts"`
+		} `yaml:"webhook"`
+		SMTP struct {
+			Host     string `yaml:"host"`
+			Port     int    `yaml:"port"`
+			Username string `yaml:"username"`
+			Password string `env:"CRYPTOSERVICE_SMTP_PASSWORD"`
+			From     string `yaml:"from"`
+		} `yaml:"smtp"`
 	} `yaml:"external"`
 
 	Storages struct {
 		Cache struct {
-			Host             string `env:"CRYPTOSERVICE_CACHE_HOST"`
-			Port             string `env:"CRYPTOSERVICE_CACHE_PORT"`
-			Password         string `env:"CRYPTOSERVICE_CACHE_PASSWORD"`
-			DBIndex          int    `yaml:"db_index"`
-			WalletBalanceTTL int64  `yaml:"wallet_balance_ttl"`
+			Enabled             bool     `yaml:"enabled"`
+			Mode                string   `yaml:"mode"`
+			Host                string   `env:"CRYPTOSERVICE_CACHE_HOST"`
+			Port                string   `env:"CRYPTOSERVICE_CACHE_PORT"`
+			Addresses           []string `yaml:"addresses" env:"CRYPTOSERVICE_CACHE_ADDRESSES" env-separator:","`
+			Username            string   `env:"CRYPTOSERVICE_CACHE_USERNAME"`
+			Password            string   `env:"CRYPTOSERVICE_CACHE_PASSWORD"`
+			DBIndex             int      `yaml:"db_index"`
+			HealthCheckInterval int64    `yaml:"health_check_interval"`
+			Sentinel            struct {
+				MasterName string `yaml:"master_name" env:"CRYPTOSERVICE_CACHE_SENTINEL_MASTER_NAME"`
+				Username   string `env:"CRYPTOSERVICE_CACHE_SENTINEL_USERNAME"`
+				Password   string `env:"CRYPTOSERVICE_CACHE_SENTINEL_PASSWORD"`
+			} `yaml:"sentinel"`
+			TLS struct {
+				Enabled            bool   `yaml:"enabled"`
+				CAFile             string `yaml:"ca_file"`
+				CertFile           string `yaml:"cert_file"`
+				KeyFile            string `yaml:"key_file"`
+				ServerName         string `yaml:"server_name"`
+				InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+			} `yaml:"tls"`
+			Breaker struct {
+				FailureThreshold int   `yaml:"failure_threshold"`
+				Cooldown         int64 `yaml:"cooldown"`
+			} `yaml:"breaker"`
+			WalletBalanceTTL struct {
+				Ethereum int64 `yaml:"ethereum"`
+				Tron     int64 `yaml:"tron"`
+			} `yaml:"wallet_balance_ttl"`
+			WalletBalanceStaleTTL struct {
+				Ethereum int64 `yaml:"ethereum"`
+				Tron     int64 `yaml:"tron"`
+			} `yaml:"wallet_balance_stale_ttl"`
+			TrackedTransactionTTL int64  `yaml:"tracked_transaction_ttl"`
+			SweepTTL              int64  `yaml:"sweep_ttl"`
+			InvoiceTTL            int64  `yaml:"invoice_ttl"`
+			WebhookDeliveryTTL    int64  `yaml:"webhook_delivery_ttl"`
+			BalanceChangesSize    int64  `yaml:"balance_changes_size"`
+			BlacklistTTL          int64  `yaml:"blacklist_ttl"`
+			FinalTransactionTTL   int64  `yaml:"final_transaction_ttl"`
+			PendingTransactionTTL int64  `yaml:"pending_transaction_ttl"`
+			EventStreamPrefix     string `yaml:"event_stream_prefix"`
+			EventStreamMaxLen     int64  `yaml:"event_stream_max_len"`
 		} `yaml:"cache"`
+		Memory struct {
+			Enabled    bool  `yaml:"enabled"`
+			TTL        int64 `yaml:"ttl"`
+			MaxEntries int   `yaml:"max_entries"`
+		} `yaml:"memory"`
 	} `yaml:"storages"`
+
+	Service struct {
+		Tracker struct {
+			PollInterval int64 `yaml:"poll_interval"`
+			DropTimeout  int64 `yaml:"drop_timeout"`
+		} `yaml:"tracker"`
+		Sweeper struct {
+			Enabled  bool           `yaml:"enabled"`
+			Interval int64          `yaml:"interval"`
+			Ethereum SweeperNetwork `yaml:"ethereum" env-prefix:"CRYPTOSERVICE_SWEEPER_ETHEREUM_"`
+			Tron     SweeperNetwork `yaml:"tron" env-prefix:"CRYPTOSERVICE_SWEEPER_TRON_"`
+		} `yaml:"sweeper"`
+		TransferWatcher struct {
+			PollInterval int64  `yaml:"poll_interval"`
+			MaxBlocks    uint64 `yaml:"max_blocks"`
+		} `yaml:"transfer_watcher"`
+		Invoices struct {
+			DefaultExpiry int64 `yaml:"default_expiry"`
+			PaymentGrace  int64 `yaml:"payment_grace"`
+			CheckInterval int64 `yaml:"check_interval"`
+			Addresses     struct {
+				Ethereum []string `yaml:"ethereum"`
+				Tron     []string `yaml:"tron"`
+			} `yaml:"addresses"`
+		} `yaml:"invoices"`
+		Webhooks struct {
+			DispatchInterval int64 `yaml:"dispatch_interval"`
+			MaxAttempts      int   `yaml:"max_attempts"`
+			BackoffBase      int64 `yaml:"backoff_base"`
+			BackoffMax       int64 `yaml:"backoff_max"`
+			DeliveryLogSize  int64 `yaml:"delivery_log_size"`
+			LeaseTimeout     int64 `yaml:"lease_timeout"`
+		} `yaml:"webhooks"`
+		Watchlist struct {
+			PollInterval int64 `yaml:"poll_interval"`
+		} `yaml:"watchlist"`
+		Events struct {
+			Enabled bool `yaml:"enabled"`
+		} `yaml:"events"`
+		BalanceCache struct {
+			StaleWhileRevalidate bool `yaml:"stale_while_revalidate"`
+			StaleIfError         bool `yaml:"stale_if_error"`
+			InvalidateOnTransfer bool `yaml:"invalidate_on_transfer"`
+		} `yaml:"balance_cache"`
+		Coalescing struct {
+			LockTTL            int64 `yaml:"lock_ttl"`
+			LockPollIntervalMs int64 `yaml:"lock_poll_interval_ms"`
+		} `yaml:"coalescing"`
+		Batch struct {
+			MaxBalances     int `yaml:"max_balances"`
+			MaxTransactions int `yaml:"max_transactions"`
+			Parallelism     int `yaml:"parallelism"`
+		} `yaml:"batch"`
+		Screening struct {
+			Enabled        bool     `yaml:"enabled"`
+			Files          []string `yaml:"files"`
+			ReloadInterval int64    `yaml:"reload_interval"`
+		} `yaml:"screening"`
+		Alerts struct {
+			Enabled       bool          `yaml:"enabled"`
+			CheckInterval int64         `yaml:"check_interval"`
+			Wallets       []AlertWallet `yaml:"wallets"`
+			Channels      struct {
+				Log     bool `yaml:"log"`
+				Webhook struct {
+					URL    string `yaml:"url"`
+					Secret string `env:"CRYPTOSERVICE_ALERTS_WEBHOOK_SECRET"`
+				} `yaml:"webhook"`
+				Email struct {
+					To []string `yaml:"to"`
+				} `yaml:"email"`
+			} `yaml:"channels"`
+		} `yaml:"alerts"`
+	} `yaml:"service"`
+}
+
+// AlertWallet is an operational wallet checked by the low balance alerts. Thresholds map
+// a token (USDT) or the native coin (ETH, TRX) to the minimum balance in its units.
+type AlertWallet struct {
+	Network    string            `yaml:"network"`
+	Address    string            `yaml:"address"`
+	Label      string            `yaml:"label"`
+	Thresholds map[string]string `yaml:"thresholds"`
+}
+
+// SweeperNetwork holds the sweep settings of a single network. Amounts are in
+// token (USDT) or native coin units, private keys are hex encoded.
+type SweeperNetwork struct {
+	DepositKeys      []string `env:"DEPOSIT_KEYS" env-separator:","`
+	GasWalletKey     string   `env:"GAS_WALLET_KEY"`
+	TreasuryAddress  string   `yaml:"treasury_address"`
+	MinAmount        string   `yaml:"min_amount"`
+	MinNativeBalance string   `yaml:"min_native_balance"`
+	TopUpAmount      string   `yaml:"top_up_amount"`
 }
 
 func MustLoad() *Config {
@@ -63,6 +231,39 @@ func MustLoad() *Config {
 	if err != nil {
 		log.Fatalf("config file cannot be readed: %s", err)
 	}
+	cfg.defaultIntervals()
 
 	return &cfg
 }
+
+// defaultIntervals replaces missing or non-positive intervals and timeouts, which would make the job tickers
+// panic or the locks never expire.
+func (cfg *Config) defaultIntervals() {
+	intervals := []struct {
+		name     string
+		value    *int64
+		fallback int64
+	}{
+		{"transport.websocket.heartbeat_interval", &cfg.Transport.WebSocket.HeartbeatInterval, 30},
+		{"external.Ethereum.follower.poll_interval", &cfg.External.Ethereum.Follower.PollInterval, 4},
+		{"external.Tron.poller.poll_interval", &cfg.External.Tron.Poller.PollInterval, 3},
+		{"storages.cache.health_check_interval", &cfg.Storages.Cache.HealthCheckInterval, 5},
+		{"service.tracker.poll_interval", &cfg.Service.Tracker.PollInterval, 15},
+		{"service.sweeper.interval", &cfg.Service.Sweeper.Interval, 60},
+		{"service.transfer_watcher.poll_interval", &cfg.Service.TransferWatcher.PollInterval, 10},
+		{"service.invoices.check_interval", &cfg.Service.Invoices.CheckInterval, 30},
+		{"service.webhooks.dispatch_interval", &cfg.Service.Webhooks.DispatchInterval, 1},
+		{"service.webhooks.lease_timeout", &cfg.Service.Webhooks.LeaseTimeout, 60},
+		{"service.watchlist.poll_interval", &cfg.Service.Watchlist.PollInterval, 60},
+		{"service.coalescing.lock_ttl", &cfg.Service.Coalescing.LockTTL, 5},
+		{"service.coalescing.lock_poll_interval_ms", &cfg.Service.Coalescing.LockPollIntervalMs, 100},
+		{"service.screening.reload_interval", &cfg.Service.Screening.ReloadInterval, 30},
+		{"service.alerts.check_interval", &cfg.Service.Alerts.CheckInterval, 300},
+	}
+	for _, interval := range intervals {
+		if *interval.value <= 0 {
+			log.Printf("%s is not set or not positive, using %d", interval.name, interval.fallback)
+			*interval.value = interval.fallback
+		}
+	}
+}
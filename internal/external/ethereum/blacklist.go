@@ -0,0 +1,56 @@
+package ethereum
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// IsBlacklisted reports whether the token issuer froze the address, using the isBlackListed method of the token contract.
+func (s *Ethereum) IsBlacklisted(ctx context.Context, address, token string) (blacklisted bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.IsBlacklisted()"),
+		slog.String("address", address),
+		slog.String("token", token),
+	)
+
+	var tokenAddress string
+	switch token {
+	case "USDT":
+		tokenAddress = usdtAddress
+	default:
+		err = ErrUnknownToken
+		logger.Warn(err.Error())
+		return
+	}
+
+	data, err := s.parsedABI.Pack("isBlackListed", common.HexToAddress(address))
+	if err != nil {
+		logger.Error("failed to pack data for isBlackListed method", slog.Any("error", err))
+		return
+	}
+
+	// invoke
+	tokenAddressCommon := common.HexToAddress(tokenAddress)
+	msg := ethereum.CallMsg{
+		To:   &tokenAddressCommon,
+		Data: data,
+	}
+	callResult, err := s.client.CallContract(ctx, msg, nil)
+	if err != nil {
+		logger.Error("failed to invoke contract with isBlackListed method", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+
+	// parse result
+	err = s.parsedABI.UnpackIntoInterface(&blacklisted, "isBlackListed", callResult)
+	if err != nil {
+		logger.Error("failed to unpack result of isBlackListed method", slog.Any("error", err))
+		return
+	}
+	return
+}
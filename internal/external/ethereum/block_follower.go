@@ -0,0 +1,217 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SubscribeBlocks registers an in-process consumer of block events. Events are delivered in chain order:
+// on a reorganisation the removed blocks come first, newest to oldest, followed by the new branch.
+// Consumers must keep reading, a full channel holds the follower back.
+// It must be called before FollowBlocks; the channel is closed when the follower stops.
+func (s *Ethereum) SubscribeBlocks() <-chan models.BlockEvent {
+	ch := make(chan models.BlockEvent, s.Config.External.Ethereum.Follower.BufferSize)
+	s.blockSubscribers = append(s.blockSubscribers, ch)
+	return ch
+}
+
+// FollowBlocks follows the chain head until ctx is done, using a new heads subscription
+// when the endpoint supports it and polling the latest header otherwise.
+func (s *Ethereum) FollowBlocks(ctx context.Context) {
+	logger := slog.With(
+		slog.String("func", "external.Ethereum.FollowBlocks()"),
+	)
+
+	slog.Info("starting Ethereum block follower...")
+	defer func() {
+		for _, ch := range s.blockSubscribers {
+			close(ch)
+		}
+		slog.Info("Ethereum block follower stopped")
+	}()
+
+	pollInterval := time.Duration(s.Config.External.Ethereum.Follower.PollInterval) * time.Second
+	for ctx.Err() == nil {
+		err := s.followNewHeads(ctx)
+		if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			logger.Info("endpoint does not support subscriptions, polling the latest block")
+			s.pollHeads(ctx, pollInterval)
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			// poll until the next subscription attempt so blocks are not missed meanwhile
+			logger.Warn("new heads subscription failed", slog.Any("error", err))
+			s.pollHead(ctx)
+			select {
+			case <-ctx.Done():
+			case <-time.After(pollInterval):
+			}
+		}
+	}
+}
+
+func (s *Ethereum) followNewHeads(ctx context.Context) (err error) {
+	headers := make(chan *types.Header)
+	sub, err := s.client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err = <-sub.Err():
+			return
+		case header := <-headers:
+			s.handleHead(ctx, header)
+		}
+	}
+}
+
+func (s *Ethereum) pollHeads(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.pollHead(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Ethereum) pollHead(ctx context.Context) {
+	header, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("failed to get latest block header",
+				slog.String("func", "external.Ethereum.pollHead()"),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+	s.handleHead(ctx, header)
+}
+
+// handleHead links the new head to the remembered chain, walking back through its ancestors
+// until a known block is found, and emits the removed and added blocks.
+func (s *Ethereum) handleHead(ctx context.Context, head *types.Header) {
+	logger := slog.With(
+		slog.String("func", "external.Ethereum.handleHead()"),
+		slog.Uint64("number", head.Number.Uint64()),
+		slog.String("hash", head.Hash().Hex()),
+	)
+
+	if known, ok := s.recentBlock(head.Number.Uint64()); ok && known.Hash == head.Hash().Hex() {
+		return
+	}
+
+	depth := s.Config.External.Ethereum.Follower.Depth
+	branch := []*types.Header{head}
+	for len(s.recentBlocks) > 0 {
+		oldest := branch[0]
+		if oldest.Number.Sign() == 0 {
+			break
+		}
+		parent, ok := s.recentBlock(oldest.Number.Uint64() - 1)
+		if ok && parent.Hash == oldest.ParentHash.Hex() {
+			break
+		}
+		if !ok && oldest.Number.Uint64()-1 < s.recentBlocks[0].Number {
+			// the branch forks below the remembered blocks
+			logger.Warn("reorganisation deeper than the follower depth, resetting")
+			s.removeBlocksAbove(ctx, 0)
+			break
+		}
+		if len(branch) >= depth {
+			// the skipped blocks are not reported, only the latest ones
+			logger.Warn("gap between known blocks and the new head exceeds the follower depth, resetting")
+			s.recentBlocks = nil
+			break
+		}
+
+		header, err := s.client.HeaderByHash(ctx, oldest.ParentHash)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Error("failed to get parent block header", slog.Any("error", err))
+			}
+			return
+		}
+		branch = append([]*types.Header{header}, branch...)
+	}
+
+	forkNumber := branch[0].Number.Uint64()
+	if len(s.recentBlocks) > 0 && forkNumber <= s.recentBlocks[len(s.recentBlocks)-1].Number {
+		logger.Info("chain reorganisation detected",
+			slog.Uint64("fork_block", forkNumber),
+			slog.Uint64("removed", s.recentBlocks[len(s.recentBlocks)-1].Number-forkNumber+1),
+		)
+	}
+	s.removeBlocksAbove(ctx, forkNumber)
+
+	for _, header := range branch {
+		event := models.BlockEvent{
+			Type:       models.BlockEventAdded,
+			Network:    "ERC20",
+			Number:     header.Number.Uint64(),
+			Hash:       header.Hash().Hex(),
+			ParentHash: header.ParentHash.Hex(),
+			Time:       time.Unix(int64(header.Time), 0).UTC(),
+		}
+		s.recentBlocks = append(s.recentBlocks, event)
+		if !s.emitBlockEvent(ctx, event) {
+			return
+		}
+	}
+
+	if len(s.recentBlocks) > depth {
+		s.recentBlocks = s.recentBlocks[len(s.recentBlocks)-depth:]
+	}
+}
+
+// removeBlocksAbove forgets the remembered blocks numbered from and above the given one, emitting them newest first.
+func (s *Ethereum) removeBlocksAbove(ctx context.Context, number uint64) {
+	for len(s.recentBlocks) > 0 && s.recentBlocks[len(s.recentBlocks)-1].Number >= number {
+		event := s.recentBlocks[len(s.recentBlocks)-1]
+		s.recentBlocks = s.recentBlocks[:len(s.recentBlocks)-1]
+
+		event.Type = models.BlockEventRemoved
+		if !s.emitBlockEvent(ctx, event) {
+			return
+		}
+	}
+}
+
+func (s *Ethereum) recentBlock(number uint64) (block models.BlockEvent, ok bool) {
+	if len(s.recentBlocks) == 0 || number < s.recentBlocks[0].Number {
+		return
+	}
+	index := number - s.recentBlocks[0].Number
+	if index >= uint64(len(s.recentBlocks)) {
+		return
+	}
+	return s.recentBlocks[index], true
+}
+
+func (s *Ethereum) emitBlockEvent(ctx context.Context, event models.BlockEvent) bool {
+	for _, ch := range s.blockSubscribers {
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// testChain builds headers for the follower tests; the branch name makes forked blocks differ in hash.
+type testChain map[string]*types.Header
+
+func (c testChain) add(name string, number uint64, parent string) *types.Header {
+	header := &types.Header{
+		Number: new(big.Int).SetUint64(number),
+		Extra:  []byte(name),
+	}
+	if parent != "" {
+		header.ParentHash = c[parent].Hash()
+	} else {
+		header.ParentHash = common.BytesToHash([]byte("unknown parent of " + name))
+	}
+	c[name] = header
+	return header
+}
+
+func (c testChain) name(hash string) string {
+	for name, header := range c {
+		if header.Hash().Hex() == hash {
+			return name
+		}
+	}
+	return hash
+}
+
+func TestHandleHead(t *testing.T) {
+	// the canonical chain a10 <- a11 <- a12 is followed before every case
+	chain := testChain{}
+	chain.add("a10", 10, "")
+	chain.add("a11", 11, "a10")
+	chain.add("a12", 12, "a11")
+	chain.add("a13", 13, "a12")
+	chain.add("b12", 12, "a11")
+	chain.add("b11", 11, "a10")
+	chain.add("c10", 10, "")
+	chain.add("d20", 20, "")
+
+	tests := []struct {
+		name       string
+		depth      int
+		head       string
+		wantEvents []string
+		wantRecent []string
+	}{
+		{
+			name:       "extends the chain",
+			head:       "a13",
+			wantEvents: []string{"added a13"},
+			wantRecent: []string{"a10", "a11", "a12", "a13"},
+		},
+		{
+			name:       "ignores a known head",
+			head:       "a12",
+			wantRecent: []string{"a10", "a11", "a12"},
+		},
+		{
+			name:       "replaces the head block",
+			head:       "b12",
+			wantEvents: []string{"removed a12", "added b12"},
+			wantRecent: []string{"a10", "a11", "b12"},
+		},
+		{
+			name:       "switches to a shorter branch",
+			head:       "b11",
+			wantEvents: []string{"removed a12", "removed a11", "added b11"},
+			wantRecent: []string{"a10", "b11"},
+		},
+		{
+			name:       "resets on a fork below the remembered blocks",
+			head:       "c10",
+			wantEvents: []string{"removed a12", "removed a11", "removed a10", "added c10"},
+			wantRecent: []string{"c10"},
+		},
+		{
+			name:       "resets on a gap beyond the depth",
+			depth:      1,
+			head:       "d20",
+			wantEvents: []string{"added d20"},
+			wantRecent: []string{"d20"},
+		},
+		{
+			name:       "keeps only the depth",
+			depth:      2,
+			head:       "a13",
+			wantEvents: []string{"added a13"},
+			wantRecent: []string{"a12", "a13"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.External.Ethereum.Follower.Depth = 64
+			cfg.External.Ethereum.Follower.BufferSize = 64
+			s := &Ethereum{Config: cfg}
+			events := s.SubscribeBlocks()
+
+			ctx := context.Background()
+			for _, name := range []string{"a10", "a11", "a12"} {
+				s.handleHead(ctx, chain[name])
+			}
+			for len(events) > 0 {
+				<-events
+			}
+			if tt.depth != 0 {
+				cfg.External.Ethereum.Follower.Depth = tt.depth
+			}
+
+			s.handleHead(ctx, chain[tt.head])
+
+			var gotEvents []string
+			for len(events) > 0 {
+				event := <-events
+				if want := chain[chain.name(event.Hash)].Number.Uint64(); event.Number != want {
+					t.Errorf("event %s has number %d, want %d", event.Hash, event.Number, want)
+				}
+				gotEvents = append(gotEvents, fmt.Sprintf("%s %s", event.Type, chain.name(event.Hash)))
+			}
+			if !slices.Equal(gotEvents, tt.wantEvents) {
+				t.Errorf("events = %v, want %v", gotEvents, tt.wantEvents)
+			}
+
+			var gotRecent []string
+			for _, block := range s.recentBlocks {
+				gotRecent = append(gotRecent, chain.name(block.Hash))
+			}
+			if !slices.Equal(gotRecent, tt.wantRecent) {
+				t.Errorf("recent blocks = %v, want %v", gotRecent, tt.wantRecent)
+			}
+		})
+	}
+}
+
+func TestRecentBlock(t *testing.T) {
+	s := &Ethereum{recentBlocks: []models.BlockEvent{{Number: 10}, {Number: 11}, {Number: 12}}}
+
+	tests := []struct {
+		number uint64
+		wantOK bool
+	}{
+		{number: 9},
+		{number: 10, wantOK: true},
+		{number: 12, wantOK: true},
+		{number: 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.number), func(t *testing.T) {
+			block, ok := s.recentBlock(tt.number)
+			if ok != tt.wantOK || (ok && block.Number != tt.number) {
+				t.Errorf("recentBlock(%d) = %d, %v, want ok %v", tt.number, block.Number, ok, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package ethereum
+
+import (
+	"fmt"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+var (
+	ErrUnknownToken        = models.NewError(models.ErrInvalidArgument, "unknown_token", "unknown token")
+	ErrTransactionNotFound = models.NewError(models.ErrNotFound, "transaction_not_found", "transaction not found")
+	ErrNotTokenTransfer    = models.NewError(models.ErrUnprocessable, "not_token_transfer", "the transaction does not involve in requested token transfers")
+	ErrNotTransferMethod   = models.NewError(models.ErrUnprocessable, "not_transfer_method", "not a transfer method")
+	ErrNodeRequestFailed   = models.NewError(models.ErrUpstream, "node_request_failed", "Ethereum node request failed")
+	ErrTransactionExpired  = models.NewError(models.ErrUnprocessable, "transaction_expired", "transaction nonce was used by another transaction")
+)
+
+// nodeError marks a failed node request, so it is reported as an upstream failure.
+func nodeError(err error) error {
+	return fmt.Errorf("%w: %w", ErrNodeRequestFailed, err)
+}
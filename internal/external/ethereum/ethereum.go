@@ -29,6 +29,10 @@ type Ethereum struct {
 	Config    *config.Config
 	client    *ethclient.Client
 	parsedABI abi.ABI
+
+	// block follower state, owned by FollowBlocks
+	blockSubscribers []chan models.BlockEvent
+	recentBlocks     []models.BlockEvent
 }
 
 func NewEthereumService(cfg *config.Config) (s *Ethereum, err error) {
@@ -55,6 +59,19 @@ func NewEthereumService(cfg *config.Config) (s *Ethereum, err error) {
 			"stateMutability": "view",
 			"type": "function"
 		  },
+		  {
+			"constant": true,
+			"inputs": [
+			  {"name": "", "type": "address"}
+			],
+			"name": "isBlackListed",
+			"outputs": [
+			  {"name": "", "type": "bool"}
+			],
+			"payable": false,
+			"stateMutability": "view",
+			"type": "function"
+		  },
 		  {
 			"inputs": [
 			  {"name": "recipient", "type": "address"},
@@ -91,6 +108,16 @@ func (s *Ethereum) Shutdown() {
 }
 
 func (s *Ethereum) GetBalance(ctx context.Context, address, token string) (balance string, err error) {
+	return s.getBalance(ctx, address, token, nil)
+}
+
+// GetBalanceAtBlock returns the token balance of the address after the block was applied.
+func (s *Ethereum) GetBalanceAtBlock(ctx context.Context, address, token string, blockNumber uint64) (balance string, err error) {
+	return s.getBalance(ctx, address, token, new(big.Int).SetUint64(blockNumber))
+}
+
+// getBalance reads the balance at the block, or at the latest one when block is nil.
+func (s *Ethereum) getBalance(ctx context.Context, address, token string, block *big.Int) (balance string, err error) {
 	logger := slog.With(
 		slog.String("request_id", ctx.Value("request_id").(string)),
 		slog.String("func", "external.Ethereum.GetBalance()"),
@@ -105,7 +132,7 @@ func (s *Ethereum) GetBalance(ctx context.Context, address, token string) (balan
 		tokenAddress = usdtAddress
 		tokenDecimals = usdtDecimals
 	default:
-		err = errors.New("unknown token")
+		err = ErrUnknownToken
 		logger.Warn(err.Error())
 		return
 	}
@@ -122,9 +149,10 @@ func (s *Ethereum) GetBalance(ctx context.Context, address, token string) (balan
 		To:   &tokenAddressCommon,
 		Data: data,
 	}
-	callResult, err := s.client.CallContract(ctx, msg, nil)
+	callResult, err := s.client.CallContract(ctx, msg, block)
 	if err != nil {
 		logger.Error("failed to invoke contract with balanceOf method", slog.Any("error", err))
+		err = nodeError(err)
 		return
 	}
 
@@ -156,30 +184,34 @@ func (s *Ethereum) GetTransaction(ctx context.Context, hash, token string) (resu
 		tokenTransferMethod = usdtTransferMethod
 		tokenDecimals = usdtDecimals
 	default:
-		err = errors.New("unknown token")
+		err = ErrUnknownToken
 		logger.Warn(err.Error())
 		return
 	}
 
 	// invoke
 	trx, _, err := s.client.TransactionByHash(ctx, common.HexToHash(hash))
+	if errors.Is(err, ethereum.NotFound) {
+		err = ErrTransactionNotFound
+		logger.Warn(err.Error())
+		return
+	}
 	if err != nil {
 		logger.Error("failed to get transaction by hash", slog.Any("error", err))
+		err = nodeError(err)
 		return
 	}
 
-	// parse result
-	trxContractAddress := strings.ToLower(trx.To().String())
-	if trxContractAddress != tokenAddress {
-		err = errors.New("the transaction does not involve in requested token transfers")
+	// parse result, contract creations have no recipient
+	if trx.To() == nil || strings.ToLower(trx.To().String()) != tokenAddress {
+		err = ErrNotTokenTransfer
 		logger.Warn(err.Error())
 		return
 	}
 
 	trxInput := trx.Data()
-	trxMethodSignature := hex.EncodeToString(trxInput[:4]) // Первые 4 байта — метод.
-	if trxMethodSignature != tokenTransferMethod {
-		err = errors.New("not a transfer method")
+	if len(trxInput) < 4 || hex.EncodeToString(trxInput[:4]) != tokenTransferMethod { // Первые 4 байта — метод.
+		err = ErrNotTransferMethod
 		logger.Warn(err.Error())
 		return
 	}
@@ -212,3 +244,13 @@ func (s *Ethereum) GetTransaction(ctx context.Context, hash, token string) (resu
 	}
 	return
 }
+
+// TokenContract returns the contract address of the token.
+func (s *Ethereum) TokenContract(token string) (contract string, err error) {
+	switch token {
+	case "USDT":
+		return usdtAddress, nil
+	}
+	err = ErrUnknownToken
+	return
+}
@@ -0,0 +1,86 @@
+package ethereum
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// GetTokenTransfers returns the Transfer events of the token emitted in the inclusive block range.
+func (s *Ethereum) GetTokenTransfers(ctx context.Context, token string, fromBlock, toBlock uint64) (transfers []models.TransferEvent, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.GetTokenTransfers()"),
+		slog.String("token", token),
+		slog.Uint64("from_block", fromBlock),
+		slog.Uint64("to_block", toBlock),
+	)
+
+	return s.filterTokenTransfers(ctx, logger, token, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+	})
+}
+
+// GetBlockTokenTransfers returns the Transfer events of the token emitted in the block with the hash.
+// Unlike a lookup by number, it also finds the transfers of a block removed by a reorganisation.
+func (s *Ethereum) GetBlockTokenTransfers(ctx context.Context, token, blockHash string) (transfers []models.TransferEvent, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.GetBlockTokenTransfers()"),
+		slog.String("token", token),
+		slog.String("block_hash", blockHash),
+	)
+
+	hash := common.HexToHash(blockHash)
+	return s.filterTokenTransfers(ctx, logger, token, ethereum.FilterQuery{BlockHash: &hash})
+}
+
+func (s *Ethereum) filterTokenTransfers(ctx context.Context, logger *slog.Logger, token string, query ethereum.FilterQuery) (transfers []models.TransferEvent, err error) {
+	var tokenAddress string
+	var tokenDecimals int
+	switch token {
+	case "USDT":
+		tokenAddress = usdtAddress
+		tokenDecimals = usdtDecimals
+	default:
+		err = ErrUnknownToken
+		logger.Warn(err.Error())
+		return
+	}
+
+	query.Addresses = []common.Address{common.HexToAddress(tokenAddress)}
+	query.Topics = [][]common.Hash{{transferEventTopic}}
+	logs, err := s.client.FilterLogs(ctx, query)
+	if err != nil {
+		logger.Error("failed to filter Transfer logs", slog.Any("error", err))
+		return
+	}
+
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) != 3 {
+			continue
+		}
+
+		transfers = append(transfers, models.TransferEvent{
+			Network:     "ERC20",
+			Token:       token,
+			Hash:        log.TxHash.Hex(),
+			LogIndex:    log.Index,
+			From:        common.BytesToAddress(log.Topics[1].Bytes()).Hex(),
+			To:          common.BytesToAddress(log.Topics[2].Bytes()).Hex(),
+			Amount:      utils.FormatCurrency(new(big.Int).SetBytes(log.Data), tokenDecimals),
+			BlockNumber: log.BlockNumber,
+		})
+	}
+	return
+}
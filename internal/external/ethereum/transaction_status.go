@@ -0,0 +1,108 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func (s *Ethereum) GetBlockNumber(ctx context.Context) (number uint64, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.GetBlockNumber()"),
+	)
+
+	number, err = s.client.BlockNumber(ctx)
+	if err != nil {
+		logger.Error("failed to get latest block number", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+	return
+}
+
+func (s *Ethereum) GetBlockTime(ctx context.Context, number uint64) (blockTime time.Time, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.GetBlockTime()"),
+		slog.Uint64("block", number),
+	)
+
+	header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		logger.Error("failed to get block header", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+	return time.Unix(int64(header.Time), 0).UTC(), nil
+}
+
+func (s *Ethereum) GetNonce(ctx context.Context, address string) (nonce uint64, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.GetNonce()"),
+		slog.String("address", address),
+	)
+
+	nonce, err = s.client.NonceAt(ctx, common.HexToAddress(address), nil)
+	if err != nil {
+		logger.Error("failed to get account nonce", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+	return
+}
+
+func (s *Ethereum) GetTransactionStatus(ctx context.Context, hash string) (status models.TransactionStatus, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.GetTransactionStatus()"),
+		slog.String("hash", hash),
+	)
+
+	trx, isPending, err := s.client.TransactionByHash(ctx, common.HexToHash(hash))
+	if errors.Is(err, ethereum.NotFound) {
+		return status, nil
+	}
+	if err != nil {
+		logger.Error("failed to get transaction by hash", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+	status.Found = true
+	status.Nonce = trx.Nonce()
+
+	sender, err := types.Sender(types.LatestSignerForChainID(trx.ChainId()), trx)
+	if err != nil {
+		logger.Error("not able to retrieve sender", slog.Any("error", err))
+		return
+	}
+	status.From = sender.Hex()
+
+	if isPending {
+		return
+	}
+
+	receipt, err := s.client.TransactionReceipt(ctx, common.HexToHash(hash))
+	if errors.Is(err, ethereum.NotFound) {
+		return status, nil
+	}
+	if err != nil {
+		logger.Error("failed to get transaction receipt", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+
+	status.Mined = true
+	status.BlockNumber = receipt.BlockNumber.Uint64()
+	status.Success = receipt.Status == types.ReceiptStatusSuccessful
+	return
+}
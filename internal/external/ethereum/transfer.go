@@ -0,0 +1,207 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"math/big"
+	"strings"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const nativeDecimals = 18
+
+func (s *Ethereum) GetNativeBalance(ctx context.Context, address string) (balance string, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.GetNativeBalance()"),
+		slog.String("address", address),
+	)
+
+	rawBalance, err := s.client.BalanceAt(ctx, common.HexToAddress(address), nil)
+	if err != nil {
+		logger.Error("failed to get ETH balance", slog.Any("error", err))
+		return
+	}
+
+	balance = utils.FormatCurrency(rawBalance, nativeDecimals)
+	return
+}
+
+func (s *Ethereum) AddressFromKey(key string) (address string, err error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return
+	}
+
+	address = crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	return
+}
+
+func (s *Ethereum) SignNativeTransfer(ctx context.Context, key, to, amount string) (signed models.SignedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.SignNativeTransfer()"),
+		slog.String("to", to),
+		slog.String("amount", amount),
+	)
+
+	value, err := utils.ParseCurrency(amount, nativeDecimals)
+	if err != nil {
+		logger.Warn("invalid ETH amount", slog.Any("error", err))
+		return
+	}
+
+	return s.signTransaction(ctx, key, common.HexToAddress(to), value, nil)
+}
+
+func (s *Ethereum) SignTokenTransfer(ctx context.Context, key, to, token, amount string) (signed models.SignedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.SignTokenTransfer()"),
+		slog.String("to", to),
+		slog.String("token", token),
+		slog.String("amount", amount),
+	)
+
+	var tokenAddress string
+	var tokenDecimals int
+	switch token {
+	case "USDT":
+		tokenAddress = usdtAddress
+		tokenDecimals = usdtDecimals
+	default:
+		err = ErrUnknownToken
+		logger.Warn(err.Error())
+		return
+	}
+
+	rawAmount, err := utils.ParseCurrency(amount, tokenDecimals)
+	if err != nil {
+		logger.Warn("invalid token amount", slog.Any("error", err))
+		return
+	}
+
+	data, err := s.parsedABI.Pack("transfer", common.HexToAddress(to), rawAmount)
+	if err != nil {
+		logger.Error("failed to pack data for transfer method", slog.Any("error", err))
+		return
+	}
+
+	return s.signTransaction(ctx, key, common.HexToAddress(tokenAddress), big.NewInt(0), data)
+}
+
+func (s *Ethereum) BroadcastTransaction(ctx context.Context, signed models.SignedTransaction) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.BroadcastTransaction()"),
+		slog.String("hash", signed.Hash),
+	)
+
+	raw, err := hex.DecodeString(signed.Raw)
+	if err != nil {
+		logger.Error("failed to decode raw transaction", slog.Any("error", err))
+		return
+	}
+
+	trx := new(types.Transaction)
+	err = trx.UnmarshalBinary(raw)
+	if err != nil {
+		logger.Error("failed to unmarshal raw transaction", slog.Any("error", err))
+		return
+	}
+
+	err = s.client.SendTransaction(ctx, trx)
+	switch {
+	case err != nil && strings.Contains(err.Error(), "already known"):
+		// rebroadcast of a transaction the node already has
+		return nil
+	case err != nil && strings.Contains(err.Error(), "nonce too low"):
+		// another transaction of the sender took the nonce, this one can never be mined
+		err = ErrTransactionExpired
+		logger.Warn(err.Error())
+		return
+	case err != nil:
+		logger.Error("failed to send transaction", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+
+	logger.Info("transaction broadcast")
+	return
+}
+
+func (s *Ethereum) signTransaction(ctx context.Context, key string, to common.Address, value *big.Int, data []byte) (signed models.SignedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Ethereum.signTransaction()"),
+	)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		logger.Error("failed to parse private key", slog.Any("error", err))
+		return
+	}
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	nonce, err := s.client.PendingNonceAt(ctx, from)
+	if err != nil {
+		logger.Error("failed to get pending nonce", slog.Any("error", err))
+		return
+	}
+
+	gasPrice, err := s.client.SuggestGasPrice(ctx)
+	if err != nil {
+		logger.Error("failed to get gas price", slog.Any("error", err))
+		return
+	}
+
+	gasLimit, err := s.client.EstimateGas(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		logger.Error("failed to estimate gas", slog.Any("error", err))
+		return
+	}
+
+	chainID, err := s.client.ChainID(ctx)
+	if err != nil {
+		logger.Error("failed to get chain ID", slog.Any("error", err))
+		return
+	}
+
+	trx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gasLimit,
+		To:       &to,
+		Value:    value,
+		Data:     data,
+	}), types.LatestSignerForChainID(chainID), privateKey)
+	if err != nil {
+		logger.Error("failed to sign transaction", slog.Any("error", err))
+		return
+	}
+
+	raw, err := trx.MarshalBinary()
+	if err != nil {
+		logger.Error("failed to marshal signed transaction", slog.Any("error", err))
+		return
+	}
+
+	signed = models.SignedTransaction{
+		Hash: trx.Hash().Hex(),
+		Raw:  hex.EncodeToString(raw),
+	}
+	return
+}
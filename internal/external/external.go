@@ -3,12 +3,16 @@ package external
 import (
 	"github.com/OwodDEV/crypto-service/internal/config"
 	"github.com/OwodDEV/crypto-service/internal/external/ethereum"
+	"github.com/OwodDEV/crypto-service/internal/external/smtp"
 	"github.com/OwodDEV/crypto-service/internal/external/tron"
+	"github.com/OwodDEV/crypto-service/internal/external/webhook"
 )
 
 type External struct {
 	Ethereum *ethereum.Ethereum
 	Tron     *tron.Tron
+	Webhook  *webhook.Webhook
+	SMTP     *smtp.SMTP
 }
 
 func NewExternal(cfg *config.Config) (external *External, err error) {
@@ -23,5 +27,15 @@ func NewExternal(cfg *config.Config) (external *External, err error) {
 		return
 	}
 
+	external.Webhook, err = webhook.NewWebhookService(cfg)
+	if err != nil {
+		return
+	}
+
+	external.SMTP, err = smtp.NewSMTPService(cfg)
+	if err != nil {
+		return
+	}
+
 	return
 }
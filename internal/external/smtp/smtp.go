@@ -0,0 +1,50 @@
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+)
+
+type SMTP struct {
+	Config *config.Config
+}
+
+func NewSMTPService(cfg *config.Config) (s *SMTP, err error) {
+	s = &SMTP{
+		Config: cfg,
+	}
+	return
+}
+
+// Send sends a plain text email to the recipients.
+func (s *SMTP) Send(ctx context.Context, to []string, subject, body string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.SMTP.Send()"),
+		slog.String("subject", subject),
+	)
+
+	cfg := s.Config.External.SMTP
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		cfg.From, strings.Join(to, ", "), subject, body)
+	err = smtp.SendMail(addr, auth, cfg.From, to, []byte(msg))
+	if err != nil {
+		logger.Warn("failed to send email", slog.Any("error", err))
+		return
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+const usdtIsBlackListedMethod = "e47d6060"
+
+// IsBlacklisted reports whether the token issuer froze the address, using the isBlackListed method of the token contract.
+func (s *Tron) IsBlacklisted(ctx context.Context, addr, token string) (blacklisted bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.IsBlacklisted()"),
+		slog.String("address", addr),
+		slog.String("token", token),
+	)
+
+	var tokenAddress string
+	var tokenIsBlackListedMethod string
+	switch token {
+	case "USDT":
+		tokenAddress = usdtAddress
+		tokenIsBlackListedMethod = usdtIsBlackListedMethod
+	default:
+		err = ErrUnknownToken
+		logger.Warn(err.Error())
+		return
+	}
+
+	addrBytes21, err := address.Base58ToAddress(addr)
+	if err != nil {
+		logger.Warn("failed to convert address to 21 bytes format", slog.Any("error", err))
+		err = ErrInvalidAddress
+		return
+	}
+	addrBytes20 := addrBytes21.Bytes()[1:] // remove first byte of version
+	addrPadded := make([]byte, 32)
+	copy(addrPadded[12:], addrBytes20)
+
+	// invoke
+	data := tokenIsBlackListedMethod + hex.EncodeToString(addrPadded)
+	callResult, err := s.client.TRC20Call(addr, tokenAddress, data, true, 0)
+	if err != nil {
+		logger.Error("failed to invoke contract with isBlackListed method", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+
+	// parse result
+	if len(callResult.ConstantResult) == 0 {
+		err = errors.New("isBlackListed method has no result")
+		logger.Warn(err.Error())
+		return
+	}
+
+	blacklisted = new(big.Int).SetBytes(callResult.ConstantResult[0]).Sign() != 0
+	return
+}
@@ -0,0 +1,172 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/google/uuid"
+)
+
+type trc20Token struct {
+	name           string
+	transferMethod string
+	decimals       int
+}
+
+// trc20Tokens are the TRC20 contracts whose transfers are extracted by the block poller.
+var trc20Tokens = map[string]trc20Token{
+	usdtAddress: {name: "USDT", transferMethod: usdtTransferMethod, decimals: usdtDecimals},
+}
+
+// SubscribeTransfers registers an in-process consumer of the TRC20 transfers found by PollBlocks,
+// delivered in block order. Consumers must keep reading, a full channel holds the poller back.
+// It must be called before PollBlocks; the channel is closed when the poller stops.
+func (s *Tron) SubscribeTransfers() <-chan models.TransferEvent {
+	ch := make(chan models.TransferEvent, s.Config.External.Tron.Poller.BufferSize)
+	s.transferSubscribers = append(s.transferSubscribers, ch)
+	return ch
+}
+
+// PollBlocks follows the latest blocks until ctx is done and publishes the successful
+// transfer calls of registered TRC20 contracts. It resumes after the last processed block
+// saved in Cursors, or starts from the current head when there is none.
+func (s *Tron) PollBlocks(ctx context.Context) {
+	slog.Info("starting Tron block poller...")
+	defer func() {
+		for _, ch := range s.transferSubscribers {
+			close(ch)
+		}
+		slog.Info("Tron block poller stopped")
+	}()
+
+	ticker := time.NewTicker(time.Duration(s.Config.External.Tron.Poller.PollInterval) * time.Second)
+	defer ticker.Stop()
+
+	var lastBlock int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			lastBlock = s.pollCursorBlocks(context.WithValue(ctx, "request_id", uuid.New().String()), lastBlock)
+		}
+	}
+}
+
+// pollCursorBlocks loads the saved cursor before the first poll and saves it after every poll which made progress.
+func (s *Tron) pollCursorBlocks(ctx context.Context, lastBlock int64) int64 {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.pollCursorBlocks()"),
+	)
+
+	if lastBlock == 0 && s.Cursors != nil {
+		cursor, err := s.Cursors.GetBlockPollerCursor(ctx, "TRC20")
+		if err != nil {
+			// the blocks produced meanwhile are skipped rather than the poller held back
+			logger.Warn("failed to load block poller cursor, starting from the head", slog.Any("error", err))
+		}
+		lastBlock = int64(cursor)
+	}
+
+	processed := s.pollBlocks(ctx, lastBlock)
+	if processed != lastBlock && s.Cursors != nil {
+		err := s.Cursors.SaveBlockPollerCursor(ctx, "TRC20", uint64(processed))
+		if err != nil {
+			logger.Warn("failed to save block poller cursor", slog.Any("error", err))
+		}
+	}
+	return processed
+}
+
+// pollBlocks processes the blocks after lastBlock up to the head and returns the last processed block.
+func (s *Tron) pollBlocks(ctx context.Context, lastBlock int64) int64 {
+	logger := slog.With(
+		slog.String("func", "external.Tron.pollBlocks()"),
+	)
+
+	head, err := s.client.GetNowBlock()
+	if err != nil {
+		logger.Warn("failed to get latest block", slog.Any("error", err))
+		return lastBlock
+	}
+	headNumber := head.GetBlockHeader().GetRawData().GetNumber()
+	if lastBlock == 0 {
+		// first poll, the head block itself is processed
+		lastBlock = headNumber - 1
+	}
+
+	toBlock := min(headNumber, lastBlock+s.Config.External.Tron.Poller.MaxBlocks)
+	for number := lastBlock + 1; number <= toBlock; number++ {
+		block := head
+		if number != headNumber {
+			block, err = s.client.GetBlockByNum(number)
+			if err != nil {
+				logger.Warn("failed to get block", slog.Int64("block", number), slog.Any("error", err))
+				return number - 1
+			}
+		}
+
+		for _, transfer := range blockTransfers(block) {
+			for _, ch := range s.transferSubscribers {
+				select {
+				case ch <- transfer:
+				case <-ctx.Done():
+					return number - 1
+				}
+			}
+		}
+	}
+	return toBlock
+}
+
+// blockTransfers extracts the successful transfer calls of registered TRC20 contracts from the block.
+func blockTransfers(block *api.BlockExtention) (transfers []models.TransferEvent) {
+	rawHeader := block.GetBlockHeader().GetRawData()
+	blockTime := time.UnixMilli(rawHeader.GetTimestamp()).UTC()
+
+	for _, trx := range block.GetTransactions() {
+		contracts := trx.GetTransaction().GetRawData().GetContract()
+		if len(contracts) == 0 || contracts[0].GetType() != core.Transaction_Contract_TriggerSmartContract {
+			continue
+		}
+
+		// failed calls are included in blocks as well
+		results := trx.GetTransaction().GetRet()
+		if len(results) == 0 || results[0].GetContractRet() != core.Transaction_Result_SUCCESS {
+			continue
+		}
+
+		scData, err := triggerSmartContract(trx.GetTransaction())
+		if err != nil {
+			continue
+		}
+		token, ok := trc20Tokens[common.EncodeCheck(scData.ContractAddress)]
+		if !ok {
+			continue
+		}
+		to, amount, err := decodeTransferCall(scData.GetData(), token.transferMethod, token.decimals)
+		if err != nil {
+			continue
+		}
+
+		transfers = append(transfers, models.TransferEvent{
+			Network:     "TRC20",
+			Token:       token.name,
+			Hash:        hex.EncodeToString(trx.GetTxid()),
+			From:        common.EncodeCheck(scData.OwnerAddress),
+			To:          to,
+			Amount:      amount,
+			BlockNumber: uint64(rawHeader.GetNumber()),
+			BlockTime:   &blockTime,
+		})
+	}
+	return
+}
@@ -0,0 +1,153 @@
+package tron
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+func (s *Tron) GetAccountResources(ctx context.Context, addr string) (result models.GetTronAccountResourcesResp, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.GetAccountResources()"),
+		slog.String("address", addr),
+	)
+
+	addrBytes, err := address.Base58ToAddress(addr)
+	if err != nil {
+		logger.Warn("failed to convert address to 21 bytes format", slog.Any("error", err))
+		err = ErrInvalidAddress
+		return
+	}
+
+	result = models.GetTronAccountResourcesResp{
+		Address:      addr,
+		Balance:      formatSun(0),
+		Staked:       models.TronResourceAmounts{Bandwidth: formatSun(0), Energy: formatSun(0)},
+		DelegatedIn:  models.TronResourceAmounts{Bandwidth: formatSun(0), Energy: formatSun(0)},
+		DelegatedOut: models.TronResourceAmounts{Bandwidth: formatSun(0), Energy: formatSun(0)},
+		Unstaking:    []models.TronUnstake{},
+		Delegations:  []models.TronResourceDelegation{},
+	}
+
+	// not activated accounts are returned as an empty message
+	account, err := s.client.Client.GetAccount(ctx, &core.Account{Address: addrBytes.Bytes()})
+	if err != nil {
+		logger.Error("failed to get account", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+	if len(account.GetAddress()) == 0 {
+		return
+	}
+	result.Activated = true
+	result.Balance = formatSun(account.GetBalance())
+
+	resources, err := s.client.Client.GetAccountResource(ctx, &core.Account{Address: addrBytes.Bytes()})
+	if err != nil {
+		logger.Error("failed to get account resources", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+	result.Bandwidth = models.TronBandwidth{
+		FreeUsed:  resources.GetFreeNetUsed(),
+		FreeLimit: resources.GetFreeNetLimit(),
+		Used:      resources.GetNetUsed(),
+		Limit:     resources.GetNetLimit(),
+	}
+	result.Energy = models.TronEnergy{
+		Used:  resources.GetEnergyUsed(),
+		Limit: resources.GetEnergyLimit(),
+	}
+
+	// Stake 2.0
+	var stakedBandwidth, stakedEnergy, stakedTronPower int64
+	for _, frozen := range account.GetFrozenV2() {
+		switch frozen.GetType() {
+		case core.ResourceCode_BANDWIDTH:
+			stakedBandwidth += frozen.GetAmount()
+		case core.ResourceCode_ENERGY:
+			stakedEnergy += frozen.GetAmount()
+		case core.ResourceCode_TRON_POWER:
+			stakedTronPower += frozen.GetAmount()
+		}
+	}
+	result.Staked = models.TronResourceAmounts{
+		Bandwidth: formatSun(stakedBandwidth),
+		Energy:    formatSun(stakedEnergy),
+		TronPower: formatSun(stakedTronPower),
+	}
+
+	for _, unfrozen := range account.GetUnfrozenV2() {
+		result.Unstaking = append(result.Unstaking, models.TronUnstake{
+			Resource:   unfrozen.GetType().String(),
+			Amount:     formatSun(unfrozen.GetUnfreezeAmount()),
+			ExpireTime: unfrozen.GetUnfreezeExpireTime(),
+		})
+	}
+
+	result.DelegatedIn = models.TronResourceAmounts{
+		Bandwidth: formatSun(account.GetAcquiredDelegatedFrozenV2BalanceForBandwidth()),
+		Energy:    formatSun(account.GetAccountResource().GetAcquiredDelegatedFrozenV2BalanceForEnergy()),
+	}
+	result.DelegatedOut = models.TronResourceAmounts{
+		Bandwidth: formatSun(account.GetDelegatedFrozenV2BalanceForBandwidth()),
+		Energy:    formatSun(account.GetAccountResource().GetDelegatedFrozenV2BalanceForEnergy()),
+	}
+
+	result.Delegations, err = s.getDelegations(ctx, addrBytes.Bytes())
+	if err != nil {
+		logger.Error("failed to get resource delegations", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+	return
+}
+
+// getDelegations returns the Stake 2.0 delegations made to and by the account.
+func (s *Tron) getDelegations(ctx context.Context, addrBytes []byte) (delegations []models.TronResourceDelegation, err error) {
+	delegations = []models.TronResourceDelegation{}
+
+	index, err := s.client.Client.GetDelegatedResourceAccountIndexV2(ctx, &api.BytesMessage{Value: addrBytes})
+	if err != nil {
+		return
+	}
+
+	pairs := make([]*api.DelegatedResourceMessage, 0, len(index.GetFromAccounts())+len(index.GetToAccounts()))
+	for _, from := range index.GetFromAccounts() {
+		pairs = append(pairs, &api.DelegatedResourceMessage{FromAddress: from, ToAddress: addrBytes})
+	}
+	for _, to := range index.GetToAccounts() {
+		pairs = append(pairs, &api.DelegatedResourceMessage{FromAddress: addrBytes, ToAddress: to})
+	}
+
+	for _, pair := range pairs {
+		list, err := s.client.Client.GetDelegatedResourceV2(ctx, pair)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range list.GetDelegatedResource() {
+			delegations = append(delegations, models.TronResourceDelegation{
+				From:                common.EncodeCheck(resource.GetFrom()),
+				To:                  common.EncodeCheck(resource.GetTo()),
+				Bandwidth:           formatSun(resource.GetFrozenBalanceForBandwidth()),
+				Energy:              formatSun(resource.GetFrozenBalanceForEnergy()),
+				BandwidthExpireTime: resource.GetExpireTimeForBandwidth(),
+				EnergyExpireTime:    resource.GetExpireTimeForEnergy(),
+			})
+		}
+	}
+	return
+}
+
+func formatSun(amount int64) string {
+	return utils.FormatCurrency(big.NewInt(amount), nativeDecimals)
+}
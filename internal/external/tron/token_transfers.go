@@ -0,0 +1,86 @@
+package tron
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// keccak256("Transfer(address,address,uint256)")
+const transferEventTopic = "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// GetTokenTransfers returns the Transfer events of the token emitted in the inclusive block range.
+func (s *Tron) GetTokenTransfers(ctx context.Context, token string, fromBlock, toBlock uint64) (transfers []models.TransferEvent, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.GetTokenTransfers()"),
+		slog.String("token", token),
+		slog.Uint64("from_block", fromBlock),
+		slog.Uint64("to_block", toBlock),
+	)
+
+	var tokenAddress string
+	var tokenDecimals int
+	switch token {
+	case "USDT":
+		tokenAddress = usdtAddress
+		tokenDecimals = usdtDecimals
+	default:
+		err = ErrUnknownToken
+		logger.Warn(err.Error())
+		return
+	}
+
+	tokenAddressBytes, err := address.Base58ToAddress(tokenAddress)
+	if err != nil {
+		logger.Error("failed to convert token address to 21 bytes format", slog.Any("error", err))
+		return
+	}
+	contractAddress := tokenAddressBytes.Bytes()[1:] // logs use 20 bytes addresses
+	topic, _ := hex.DecodeString(transferEventTopic)
+
+	for number := fromBlock; number <= toBlock; number++ {
+		infos, err := s.client.GetBlockInfoByNum(int64(number))
+		if err != nil {
+			logger.Error("failed to get block transactions info", slog.Uint64("block", number), slog.Any("error", err))
+			return nil, err
+		}
+
+		for _, info := range infos.GetTransactionInfo() {
+			if info.GetResult() != core.TransactionInfo_SUCESS {
+				continue
+			}
+			blockTime := time.UnixMilli(info.GetBlockTimeStamp()).UTC()
+
+			for i, log := range info.GetLog() {
+				topics := log.GetTopics()
+				if !bytes.Equal(log.GetAddress(), contractAddress) || len(topics) != 3 || !bytes.Equal(topics[0], topic) {
+					continue
+				}
+
+				transfers = append(transfers, models.TransferEvent{
+					Network:     "TRC20",
+					Token:       token,
+					Hash:        hex.EncodeToString(info.GetId()),
+					LogIndex:    uint(i),
+					From:        common.EncodeCheck(append([]byte{0x41}, topics[1][12:]...)),
+					To:          common.EncodeCheck(append([]byte{0x41}, topics[2][12:]...)),
+					Amount:      utils.FormatCurrency(new(big.Int).SetBytes(log.GetData()), tokenDecimals),
+					BlockNumber: number,
+					BlockTime:   &blockTime,
+				})
+			}
+		}
+	}
+	return
+}
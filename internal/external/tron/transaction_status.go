@@ -0,0 +1,78 @@
+package tron
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func (s *Tron) GetBlockNumber(ctx context.Context) (number uint64, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.GetBlockNumber()"),
+	)
+
+	block, err := s.client.GetNowBlock()
+	if err != nil {
+		logger.Error("failed to get latest block", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+
+	number = uint64(block.GetBlockHeader().GetRawData().GetNumber())
+	return
+}
+
+func (s *Tron) GetTransactionStatus(ctx context.Context, hash string) (status models.TransactionStatus, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.GetTransactionStatus()"),
+		slog.String("hash", hash),
+	)
+
+	hashBytes, err := common.FromHex(hash)
+	if err != nil {
+		logger.Warn("failed to decode transaction hash", slog.Any("error", err))
+		err = ErrInvalidHash
+		return
+	}
+
+	// the client helper reports a missing transaction as a generic error,
+	// so the raw gRPC call is used to tell "not yet in a block" from a failure
+	info, err := s.client.Client.GetTransactionInfoById(ctx, &api.BytesMessage{Value: hashBytes})
+	if err != nil {
+		logger.Error("failed to get transaction info by hash", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+	if len(info.GetId()) == 0 {
+		// not in a block yet, the transaction may still wait in the node's pending pool
+		var pending *core.Transaction
+		pending, err = s.client.Client.GetTransactionFromPending(ctx, &api.BytesMessage{Value: hashBytes})
+		if grpcstatus.Code(err) == codes.Unimplemented {
+			return status, nil
+		}
+		if err != nil {
+			logger.Error("failed to get pending transaction by hash", slog.Any("error", err))
+			err = nodeError(err)
+			return
+		}
+		status.Found = pending.GetRawData() != nil
+		return
+	}
+
+	receiptResult := info.GetReceipt().GetResult()
+	status.Found = true
+	status.Mined = true
+	status.BlockNumber = uint64(info.GetBlockNumber())
+	status.Success = info.GetResult() == core.TransactionInfo_SUCESS &&
+		(receiptResult == core.Transaction_Result_SUCCESS || receiptResult == core.Transaction_Result_DEFAULT)
+	return
+}
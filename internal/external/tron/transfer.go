@@ -0,0 +1,213 @@
+package tron
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+const nativeDecimals = 6
+
+func (s *Tron) GetNativeBalance(ctx context.Context, addr string) (balance string, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.GetNativeBalance()"),
+		slog.String("address", addr),
+	)
+
+	addrBytes, err := address.Base58ToAddress(addr)
+	if err != nil {
+		logger.Warn("failed to convert address to 21 bytes format", slog.Any("error", err))
+		return
+	}
+
+	// not activated accounts are returned as an empty message
+	account, err := s.client.Client.GetAccount(ctx, &core.Account{Address: addrBytes.Bytes()})
+	if err != nil {
+		logger.Error("failed to get account", slog.Any("error", err))
+		return
+	}
+
+	balance = formatSun(account.GetBalance())
+	return
+}
+
+func (s *Tron) AddressFromKey(key string) (addr string, err error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return
+	}
+
+	addr = address.PubkeyToAddress(privateKey.PublicKey).String()
+	return
+}
+
+func (s *Tron) SignNativeTransfer(ctx context.Context, key, to, amount string) (signed models.SignedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.SignNativeTransfer()"),
+		slog.String("to", to),
+		slog.String("amount", amount),
+	)
+
+	from, err := s.AddressFromKey(key)
+	if err != nil {
+		logger.Error("failed to parse private key", slog.Any("error", err))
+		return
+	}
+
+	value, err := utils.ParseCurrency(amount, nativeDecimals)
+	if err != nil {
+		logger.Warn("invalid TRX amount", slog.Any("error", err))
+		return
+	}
+
+	trx, err := s.client.Transfer(from, to, value.Int64())
+	if err != nil {
+		logger.Error("failed to create TRX transfer", slog.Any("error", err))
+		return
+	}
+
+	return s.signTransaction(ctx, key, trx)
+}
+
+func (s *Tron) SignTokenTransfer(ctx context.Context, key, to, token, amount string) (signed models.SignedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.SignTokenTransfer()"),
+		slog.String("to", to),
+		slog.String("token", token),
+		slog.String("amount", amount),
+	)
+
+	var tokenAddress string
+	var tokenDecimals int
+	switch token {
+	case "USDT":
+		tokenAddress = usdtAddress
+		tokenDecimals = usdtDecimals
+	default:
+		err = ErrUnknownToken
+		logger.Warn(err.Error())
+		return
+	}
+
+	from, err := s.AddressFromKey(key)
+	if err != nil {
+		logger.Error("failed to parse private key", slog.Any("error", err))
+		return
+	}
+
+	rawAmount, err := utils.ParseCurrency(amount, tokenDecimals)
+	if err != nil {
+		logger.Warn("invalid token amount", slog.Any("error", err))
+		return
+	}
+
+	trx, err := s.client.TRC20Send(from, to, tokenAddress, rawAmount, s.Config.External.Tron.FeeLimit)
+	if err != nil {
+		logger.Error("failed to create token transfer", slog.Any("error", err))
+		return
+	}
+
+	return s.signTransaction(ctx, key, trx)
+}
+
+func (s *Tron) BroadcastTransaction(ctx context.Context, signed models.SignedTransaction) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.BroadcastTransaction()"),
+		slog.String("hash", signed.Hash),
+	)
+
+	raw, err := hex.DecodeString(signed.Raw)
+	if err != nil {
+		logger.Error("failed to decode raw transaction", slog.Any("error", err))
+		return
+	}
+
+	trx := new(core.Transaction)
+	err = proto.Unmarshal(raw, trx)
+	if err != nil {
+		logger.Error("failed to unmarshal raw transaction", slog.Any("error", err))
+		return
+	}
+
+	if time.Now().UnixMilli() >= trx.GetRawData().GetExpiration() {
+		err = ErrTransactionExpired
+		logger.Warn(err.Error())
+		return
+	}
+
+	result, err := s.client.Broadcast(trx)
+	switch result.GetCode() {
+	case api.Return_DUP_TRANSACTION_ERROR:
+		// rebroadcast of a transaction the node already has
+		return nil
+	case api.Return_TRANSACTION_EXPIRATION_ERROR:
+		err = ErrTransactionExpired
+		logger.Warn(err.Error())
+		return
+	}
+	if err != nil {
+		logger.Error("failed to broadcast transaction", slog.Any("error", err))
+		err = nodeError(err)
+		return
+	}
+
+	logger.Info("transaction broadcast")
+	return
+}
+
+func (s *Tron) signTransaction(ctx context.Context, key string, trx *api.TransactionExtention) (signed models.SignedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Tron.signTransaction()"),
+	)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		logger.Error("failed to parse private key", slog.Any("error", err))
+		return
+	}
+
+	rawData, err := proto.Marshal(trx.GetTransaction().GetRawData())
+	if err != nil {
+		logger.Error("failed to marshal transaction raw data", slog.Any("error", err))
+		return
+	}
+	hash := sha256.Sum256(rawData)
+
+	signature, err := crypto.Sign(hash[:], privateKey)
+	if err != nil {
+		logger.Error("failed to sign transaction", slog.Any("error", err))
+		return
+	}
+	trx.Transaction.Signature = append(trx.Transaction.Signature, signature)
+
+	raw, err := proto.Marshal(trx.GetTransaction())
+	if err != nil {
+		logger.Error("failed to marshal signed transaction", slog.Any("error", err))
+		return
+	}
+
+	expiresAt := time.UnixMilli(trx.GetTransaction().GetRawData().GetExpiration()).UTC()
+	signed = models.SignedTransaction{
+		Hash:      hex.EncodeToString(hash[:]),
+		Raw:       hex.EncodeToString(raw),
+		ExpiresAt: &expiresAt,
+	}
+	return
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
@@ -27,9 +28,19 @@ const (
 	usdtDecimals       = 6
 )
 
+// CursorStorage keeps the last block processed by the poller across restarts.
+type CursorStorage interface {
+	SaveBlockPollerCursor(ctx context.Context, network string, block uint64) (err error)
+	GetBlockPollerCursor(ctx context.Context, network string) (block uint64, err error)
+}
+
 type Tron struct {
-	Config *config.Config
-	client *client.GrpcClient
+	Config  *config.Config
+	Cursors CursorStorage
+	client  *client.GrpcClient
+
+	// block poller consumers, registered before PollBlocks starts
+	transferSubscribers []chan models.TransferEvent
 }
 
 func NewTronService(cfg *config.Config) (s *Tron, err error) {
@@ -73,14 +84,15 @@ func (s *Tron) GetBalance(ctx context.Context, addr, token string) (balance stri
 		tokenBalanceMethod = usdtBalanceMethod
 		tokenDecimals = usdtDecimals
 	default:
-		err = errors.New("unknown token")
+		err = ErrUnknownToken
 		logger.Warn(err.Error())
 		return
 	}
 
 	addrBytes21, err := address.Base58ToAddress(addr)
 	if err != nil {
-		logger.Error("failed to convert address to 21 bytes format", slog.Any("error", err))
+		logger.Warn("failed to convert address to 21 bytes format", slog.Any("error", err))
+		err = ErrInvalidAddress
 		return
 	}
 	addrBytes20 := addrBytes21.Bytes()[1:] // remove first byte of version
@@ -92,6 +104,7 @@ func (s *Tron) GetBalance(ctx context.Context, addr, token string) (balance stri
 	callResult, err := s.client.TRC20Call(addr, tokenAddress, data, true, 0)
 	if err != nil {
 		logger.Error("failed to invoke contract with balanceOf method", slog.Any("error", err))
+		err = nodeError(err)
 		return
 	}
 
@@ -125,75 +138,112 @@ func (s *Tron) GetTransaction(ctx context.Context, hash, token string) (result m
 		tokenTransferMethod = usdtTransferMethod
 		tokenDecimals = usdtDecimals
 	default:
-		err = errors.New("unknown token")
+		err = ErrUnknownToken
 		logger.Warn(err.Error())
 		return
 	}
 
-	trx, err := s.client.GetTransactionByID(hash)
+	hashBytes, err := common.FromHex(hash)
 	if err != nil {
-		logger.Error("failed to get transaction by hash", slog.Any("error", err))
+		logger.Warn("failed to decode transaction hash", slog.Any("error", err))
+		err = ErrInvalidHash
 		return
 	}
 
-	trxContract := trx.GetRawData().GetContract()
-	if len(trxContract) == 0 {
-		err = errors.New("failed to get contract")
-		logger.Error(err.Error())
+	// the client helper reports a missing transaction as a generic error
+	trx, err := s.client.Client.GetTransactionById(ctx, &api.BytesMessage{Value: hashBytes})
+	if err != nil {
+		logger.Error("failed to get transaction by hash", slog.Any("error", err))
+		err = nodeError(err)
 		return
 	}
-
-	parameter := trxContract[0].GetParameter()
-	if parameter == nil {
-		err = errors.New("failed to get parameter of contract")
-		logger.Error(err.Error())
+	if proto.Size(trx) == 0 {
+		err = ErrTransactionNotFound
+		logger.Warn(err.Error())
 		return
 	}
 
-	scData := core.TriggerSmartContract{}
-	err = proto.Unmarshal(parameter.GetValue(), &scData)
+	scData, err := triggerSmartContract(trx)
 	if err != nil {
-		logger.Error("failed to unmarshal smartcontract data", slog.Any("error", err))
+		logger.Error(err.Error())
 		return
 	}
 
 	trxContractAddress := common.EncodeCheck(scData.ContractAddress)
 	if trxContractAddress != tokenAddress {
-		err = errors.New("the transaction does not involve in requested token transfers")
+		err = ErrNotTokenTransfer
 		logger.Warn(err.Error())
 		return
 	}
 
-	trxInput := scData.GetData()
-	if len(trxInput) != 4+32+32 { // 4 bytes for signature, 2 params
-		err = errors.New("unknown input data")
+	trxTo, trxAmount, err := decodeTransferCall(scData.GetData(), tokenTransferMethod, tokenDecimals)
+	if err != nil {
 		logger.Warn(err.Error())
 		return
 	}
+	trxFrom := common.EncodeCheck(scData.OwnerAddress)
+
+	result = models.Transaction{
+		Hash:   hash,
+		From:   trxFrom,
+		To:     trxTo,
+		Amount: trxAmount,
+	}
+	return
+}
+
+// triggerSmartContract returns the smart contract call of the transaction.
+func triggerSmartContract(trx *core.Transaction) (scData *core.TriggerSmartContract, err error) {
+	trxContract := trx.GetRawData().GetContract()
+	if len(trxContract) == 0 {
+		err = ErrNotTokenTransfer
+		return
+	}
+
+	parameter := trxContract[0].GetParameter()
+	if parameter == nil {
+		err = ErrNotTokenTransfer
+		return
+	}
+
+	scData = &core.TriggerSmartContract{}
+	err = proto.Unmarshal(parameter.GetValue(), scData)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal smartcontract data: %w", err)
+		return
+	}
+	return
+}
+
+// decodeTransferCall decodes the recipient and amount of a transfer(address,uint256) call data.
+func decodeTransferCall(trxInput []byte, tokenTransferMethod string, tokenDecimals int) (to, amount string, err error) {
+	if len(trxInput) != 4+32+32 { // 4 bytes for signature, 2 params
+		err = ErrNotTransferMethod
+		return
+	}
 
 	trxMethodSignature := hex.EncodeToString(trxInput[:4])
-	fmt.Println("Method Signature:", trxMethodSignature)
 	if trxMethodSignature != tokenTransferMethod {
-		err = errors.New("not a transfer method")
-		logger.Warn(err.Error())
+		err = ErrNotTransferMethod
 		return
 	}
 
-	trxFrom := common.EncodeCheck(scData.OwnerAddress)
-
 	trxParams := trxInput[4:]
 	trxToBytes := append([]byte{0x41}, trxParams[12:32]...)
-	trxTo := common.EncodeCheck(trxToBytes)
+	to = common.EncodeCheck(trxToBytes)
 
 	trxAmountBytes := trxParams[32:]
 	trxAmountRaw := new(big.Int).SetBytes(trxAmountBytes)
-	trxAmount := utils.FormatCurrency(trxAmountRaw, tokenDecimals)
+	amount = utils.FormatCurrency(trxAmountRaw, tokenDecimals)
+	return
+}
 
-	result = models.Transaction{
-		Hash:   hash,
-		From:   trxFrom,
-		To:     trxTo,
-		Amount: trxAmount,
+// TokenContract returns the contract address of the token.
+func (s *Tron) TokenContract(token string) (contract string, err error) {
+	switch token {
+	case "USDT":
+		return usdtAddress, nil
 	}
+	err = ErrUnknownToken
 	return
 }
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+var ErrForbiddenTarget = models.NewError(models.ErrInvalidArgument, "forbidden_webhook_target", "webhook URL must be a public http or https address")
+
+// ValidateURL checks that a subscriber URL uses http or https and that its host resolves to public
+// addresses only, unless the host is allowed in the config.
+func (s *Webhook) ValidateURL(ctx context.Context, rawURL string) (err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrForbiddenTarget
+	}
+	if s.isAllowedHost(u.Hostname()) {
+		return nil
+	}
+	_, err = resolvePublic(ctx, u.Hostname())
+	return
+}
+
+// newTransport returns a transport dialing public addresses only. The address is checked at
+// connection time as well, so DNS changes after the subscription and redirects are covered.
+func (s *Webhook) newTransport() *http.Transport {
+	dialer := &net.Dialer{Timeout: time.Duration(s.Config.External.Webhook.Timeout) * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would make the checked address the proxy's
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if s.isAllowedHost(host) {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		ips, err := resolvePublic(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].String(), port))
+	}
+	return transport
+}
+
+func (s *Webhook) isAllowedHost(host string) bool {
+	return slices.ContainsFunc(s.allowedHosts, func(allowed string) bool {
+		return strings.EqualFold(allowed, host)
+	})
+}
+
+// resolvePublic resolves the host and fails when any of its addresses is not public.
+func resolvePublic(ctx context.Context, host string) (ips []net.IP, err error) {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrForbiddenTarget, err)
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return nil, ErrForbiddenTarget
+		}
+		ips = append(ips, addr.IP)
+	}
+	if len(ips) == 0 {
+		return nil, ErrForbiddenTarget
+	}
+	return
+}
+
+// specialUseNetworks are the special-purpose ranges (RFC 6890) not covered by the net.IP checks: shared
+// CGNAT space, benchmarking, documentation and reserved ranges, and the IPv6 translation and tunnelling
+// prefixes which may embed an internal IPv4 address.
+var specialUseNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"192.88.99.0/24",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"240.0.0.0/4",
+	"64:ff9b::/96",
+	"64:ff9b:1::/48",
+	"100::/64",
+	"2001::/23",
+	"2001:db8::/32",
+	"2002::/16",
+)
+
+func mustParseCIDRs(cidrs ...string) (networks []*net.IPNet) {
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range specialUseNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventIDHeader   = "X-Webhook-Event-ID"
+)
+
+type Webhook struct {
+	Config       *config.Config
+	client       *http.Client
+	allowedHosts []string
+}
+
+func NewWebhookService(cfg *config.Config) (s *Webhook, err error) {
+	s = &Webhook{
+		Config:       cfg,
+		allowedHosts: cfg.External.Webhook.AllowedHosts,
+	}
+	// the alerts webhook comes from the config, so it may point to an internal host
+	if alertsURL, err := url.Parse(cfg.Service.Alerts.Channels.Webhook.URL); err == nil && alertsURL.Hostname() != "" {
+		s.allowedHosts = append(s.allowedHosts, alertsURL.Hostname())
+	}
+	s.client = &http.Client{
+		Timeout:   time.Duration(cfg.External.Webhook.Timeout) * time.Second,
+		Transport: s.newTransport(),
+	}
+	return
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send POSTs a signed JSON payload and returns the response status code.
+func (s *Webhook) Send(ctx context.Context, url, secret, eventID string, body []byte) (statusCode int, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "external.Webhook.Send()"),
+		slog.String("url", url),
+		slog.String("event_id", eventID),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		logger.Warn("failed to create webhook request", slog.Any("error", err))
+		return
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, eventID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		logger.Warn("failed to send webhook", slog.Any("error", err))
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	statusCode = resp.StatusCode
+	logger.Debug("webhook sent", slog.Int("status", statusCode))
+	return
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	BalanceAlertLow       = "low_balance"
+	BalanceAlertRecovered = "balance_recovered"
+)
+
+// BalanceAlert is sent when an operational wallet balance drops below its threshold and when it recovers.
+type BalanceAlert struct {
+	Type      string    `json:"type"`
+	Network   string    `json:"network"`
+	Address   string    `json:"address"`
+	Label     string    `json:"label"`
+	Token     string    `json:"token"`
+	Balance   string    `json:"balance"`
+	Threshold string    `json:"threshold"`
+	Time      time.Time `json:"time"`
+}
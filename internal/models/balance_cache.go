@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// CachedBalance is a cached wallet balance and the latest block known when it was read.
+// Stale entries are past their fresh TTL.
+type CachedBalance struct {
+	Balance     string    `json:"balance"`
+	CachedAt    time.Time `json:"cached_at"`
+	BlockNumber uint64    `json:"block_number,omitempty"`
+	Stale       bool      `json:"-"`
+}
+
+// WalletBalanceKey identifies a cached balance. The contract and the address are in their normalized form.
+type WalletBalanceKey struct {
+	Network  string
+	Contract string
+	Address  string
+}
+
+// CachedTransaction is a cached transaction lookup result and the latest block known when it was read.
+// Entries saved before the lookup time was recorded have a zero CachedAt.
+type CachedTransaction struct {
+	Transaction
+	CachedAt    time.Time `json:"cached_at"`
+	BlockNumber uint64    `json:"block_number,omitempty"`
+}
+
+// CacheInvalidation asks the replicas other than the publishing instance to drop an in-process cache entry.
+type CacheInvalidation struct {
+	InstanceID string `json:"instance_id"`
+	Key        string `json:"key"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+type GetWalletBalancesReq struct {
+	Items []WalletBalanceItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// WalletBalanceItem is a balance to look up. The network is detected from the address when
+// omitted, the token defaults to USDT.
+type WalletBalanceItem struct {
+	Network string `json:"network" example:"ethereum"`
+	Address string `json:"address" validate:"required" example:"0xe983fD1798689eee00c0Fb77e79B8f372DF41060"`
+	Token   string `json:"token" validate:"omitempty,oneof=USDT" example:"USDT"`
+}
+
+// WalletBalanceResult holds either the balance or the error of an item, in the order of the request.
+type WalletBalanceResult struct {
+	Network     string     `json:"network,omitempty"`
+	Address     string     `json:"address"`
+	Token       string     `json:"token"`
+	Balance     string     `json:"balance,omitempty"`
+	Source      string     `json:"source,omitempty" enums:"cache,live"`
+	CachedAt    *time.Time `json:"cached_at,omitempty"`
+	BlockNumber uint64     `json:"block_number,omitempty"`
+	Stale       bool       `json:"stale"`
+	Error       *ItemError `json:"error,omitempty"`
+	Err         error      `json:"-"`
+}
+
+type GetWalletBalancesResp struct {
+	Items []WalletBalanceResult `json:"items"`
+}
+
+type LookupTransactionsReq struct {
+	Hashes []string `json:"hashes" validate:"required,min=1,dive,required"`
+}
+
+// TransactionLookupResult holds either the transaction or the error of a hash, in the order of the request.
+type TransactionLookupResult struct {
+	Hash        string              `json:"hash"`
+	Network     string              `json:"network,omitempty"`
+	Transaction *GetTransactionResp `json:"transaction,omitempty"`
+	Error       *ItemError          `json:"error,omitempty"`
+	Err         error               `json:"-"`
+}
+
+type LookupTransactionsResp struct {
+	Items []TransactionLookupResult `json:"items"`
+}
@@ -0,0 +1,8 @@
+package models
+
+type GetBlacklistStatusResp struct {
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Token   string `json:"token"`
+	Frozen  bool   `json:"frozen"`
+}
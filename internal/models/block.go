@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+const (
+	BlockEventAdded   = "added"
+	BlockEventRemoved = "removed"
+)
+
+// BlockEvent reports a block joining or leaving the canonical chain followed by a block follower.
+type BlockEvent struct {
+	Type       string    `json:"type"`
+	Network    string    `json:"network"`
+	Number     uint64    `json:"number"`
+	Hash       string    `json:"hash"`
+	ParentHash string    `json:"parent_hash"`
+	Time       time.Time `json:"time"`
+}
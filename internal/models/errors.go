@@ -0,0 +1,46 @@
+package models
+
+import "errors"
+
+// Error kinds. Errors returned by the service wrap one of them, which selects the HTTP status code.
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrNotFound        = errors.New("not found")
+	ErrUnprocessable   = errors.New("unprocessable")
+	ErrUpstream        = errors.New("upstream failure")
+	ErrUnavailable     = errors.New("unavailable")
+	ErrForbidden       = errors.New("forbidden")
+)
+
+// Error is a sentinel error with a stable code reported to the clients.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+}
+
+func NewError(kind error, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// ErrorResp is the body of failed requests. Details hold the invalid fields of a request body.
+type ErrorResp struct {
+	Code      string            `json:"code" example:"watched_address_not_found"`
+	Message   string            `json:"message" example:"watched address not found"`
+	RequestID string            `json:"request_id"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// ItemError is the failure of a single item of a batch request.
+type ItemError struct {
+	Code    string `json:"code" example:"invalid_address"`
+	Message string `json:"message" example:"unknown address format"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// EventSchemaVersion is the version of the Event envelope and payloads. It is increased on incompatible changes.
+const EventSchemaVersion = 1
+
+const (
+	EventBalanceChanged       = "balance.changed"
+	EventTransferDetected     = "transfer.detected"
+	EventTransactionConfirmed = "transaction.confirmed"
+)
+
+// Event is published to the event bus for downstream services.
+type Event struct {
+	SchemaVersion int       `json:"schema_version"`
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Network       string    `json:"network"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	Data          any       `json:"data"`
+}
+
+// BalanceChangedEvent is the payload of balance.changed events.
+type BalanceChangedEvent struct {
+	Address  string `json:"address"`
+	Token    string `json:"token"`
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
+}
+
+// TransactionConfirmedEvent is the payload of transaction.confirmed events.
+type TransactionConfirmedEvent struct {
+	Hash          string `json:"hash"`
+	BlockNumber   uint64 `json:"block_number"`
+	Confirmations uint64 `json:"confirmations"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+
+	ComponentStatusUp       = "up"
+	ComponentStatusDown     = "down"
+	ComponentStatusDisabled = "disabled"
+)
+
+// ComponentStatus is the state of a dependency. Since is set while it is down.
+type ComponentStatus struct {
+	Status    string     `json:"status"`
+	Since     *time.Time `json:"since,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+type HealthResp struct {
+	Status string          `json:"status"`
+	Cache  ComponentStatus `json:"cache"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+const (
+	InvoicePending   = "pending"
+	InvoiceUnderpaid = "underpaid"
+	InvoicePaid      = "paid"
+	InvoiceOverpaid  = "overpaid"
+	InvoiceExpired   = "expired"
+)
+
+type Invoice struct {
+	ID        string           `json:"id"`
+	Network   string           `json:"network"`
+	Token     string           `json:"token"`
+	Address   string           `json:"address"`
+	Amount    string           `json:"amount"`
+	Received  string           `json:"received"`
+	Status    string           `json:"status"`
+	Payments  []InvoicePayment `json:"payments"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+type InvoicePayment struct {
+	Hash        string    `json:"hash"`
+	LogIndex    uint      `json:"log_index"`
+	From        string    `json:"from"`
+	Amount      string    `json:"amount"`
+	BlockNumber uint64    `json:"block_number"`
+	Time        time.Time `json:"time"`
+}
+
+type CreateInvoiceReq struct {
+	Amount    string `json:"amount" validate:"required,numeric"`
+	Token     string `json:"token" validate:"required,oneof=USDT"`
+	Network   string `json:"network" validate:"required"`
+	ExpiresIn int64  `json:"expires_in" validate:"omitempty,min=60"`
+}
@@ -1,18 +1,54 @@
 package models
 
+import "time"
+
 type Transaction struct {
-	Hash   string
-	From   string
-	To     string
-	Amount string
+	Hash   string `json:"hash"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+}
+
+const (
+	DataSourceCache = "cache"
+	DataSourceLive  = "live"
+)
+
+// CacheControl is the freshness requested by the client. NoCache asks for a live value,
+// MaxAge for a value read at most that long ago.
+type CacheControl struct {
+	NoCache bool
+	MaxAge  *time.Duration
 }
 
 type GetWalletResp struct {
-	Balance string `json:"balance"`
+	Balance     string           `json:"balance"`
+	Source      string           `json:"source" enums:"cache,live"`
+	CachedAt    *time.Time       `json:"cached_at,omitempty"`
+	BlockNumber uint64           `json:"block_number,omitempty"`
+	Stale       bool             `json:"stale"`
+	Frozen      *bool            `json:"frozen,omitempty"`
+	Screening   []ScreeningMatch `json:"screening,omitempty"`
+	// ScreeningIncomplete is set when only the screening list files could be checked
+	ScreeningIncomplete bool `json:"screening_incomplete,omitempty"`
 }
 
 type GetTransactionResp struct {
-	From   string `json:"from"`
-	To     string `json:"to"`
-	Amount string `json:"amount"`
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	Amount      string           `json:"amount"`
+	Source      string           `json:"source" enums:"cache,live"`
+	CachedAt    *time.Time       `json:"cached_at,omitempty"`
+	BlockNumber uint64           `json:"block_number,omitempty"`
+	Screening   []ScreeningMatch `json:"screening,omitempty"`
+	// ScreeningIncomplete is set when only the screening list files could be checked
+	ScreeningIncomplete bool `json:"screening_incomplete,omitempty"`
+}
+
+// SignedTransaction is a signed, serialized transaction ready to be broadcast. ExpiresAt is set
+// on networks where a transaction not included in a block by then is rejected (Tron).
+type SignedTransaction struct {
+	Hash      string     `json:"hash"`
+	Raw       string     `json:"raw"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
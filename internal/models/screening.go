@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	ScreeningPartyAddress   = "address"
+	ScreeningPartySender    = "sender"
+	ScreeningPartyRecipient = "recipient"
+
+	ScreeningSourceInternal = "internal"
+)
+
+// ScreeningEntry is a sanctioned or blocked address of a screening list.
+type ScreeningEntry struct {
+	Network   string     `json:"network"`
+	Address   string     `json:"address"`
+	Source    string     `json:"source"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// ScreeningMatch annotates a response with a screening list entry matching one of its parties.
+type ScreeningMatch struct {
+	Party   string `json:"party"`
+	Address string `json:"address"`
+	Source  string `json:"source"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type CreateScreeningEntryReq struct {
+	Network string `json:"network"`
+	Address string `json:"address" validate:"required"`
+	Reason  string `json:"reason"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+const (
+	StreamEventTransfer = "transfer"
+	StreamEventBalance  = "balance"
+
+	StreamMessageSubscribed   = "subscribed"
+	StreamMessageUnsubscribed = "unsubscribed"
+	StreamMessageError        = "error"
+
+	StreamActionSubscribe   = "subscribe"
+	StreamActionUnsubscribe = "unsubscribe"
+)
+
+// StreamEvent is an update fanned out to the WebSocket clients of every replica.
+// Addresses are normalized and list every address the event involves.
+type StreamEvent struct {
+	Type      string         `json:"type"`
+	Network   string         `json:"network"`
+	Addresses []string       `json:"addresses"`
+	Token     string         `json:"token,omitempty"`
+	Balance   string         `json:"balance,omitempty"`
+	Transfer  *TransferEvent `json:"transfer,omitempty"`
+	Time      time.Time      `json:"time"`
+}
+
+// StreamRequest is a message sent by a WebSocket client.
+type StreamRequest struct {
+	Action    string   `json:"action"`
+	Addresses []string `json:"addresses"`
+}
+
+// StreamMessage is a message pushed to a WebSocket client.
+type StreamMessage struct {
+	Type      string         `json:"type"`
+	Address   string         `json:"address,omitempty"`
+	Addresses []string       `json:"addresses,omitempty"`
+	Network   string         `json:"network,omitempty"`
+	Token     string         `json:"token,omitempty"`
+	Balance   string         `json:"balance,omitempty"`
+	Transfer  *TransferEvent `json:"transfer,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	Time      time.Time      `json:"time"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	SweepTopUpPending    = "top_up_pending"
+	SweepTransferPending = "transfer_pending"
+	SweepCompleted       = "completed"
+	SweepFailed          = "failed"
+)
+
+// Sweep is a consolidation of a deposit address balance into the treasury wallet.
+// Signed transactions are stored before they are broadcast, so an interrupted
+// sweep is resumed by rebroadcasting them instead of signing new ones.
+type Sweep struct {
+	ID        string             `json:"id"`
+	Network   string             `json:"network"`
+	Address   string             `json:"address"`
+	Amount    string             `json:"amount"`
+	State     string             `json:"state"`
+	TopUp     *SignedTransaction `json:"top_up,omitempty"`
+	Transfer  *SignedTransaction `json:"transfer,omitempty"`
+	Steps     []SweepStep        `json:"steps"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type SweepStep struct {
+	State string    `json:"state"`
+	Hash  string    `json:"hash,omitempty"`
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+func (s Sweep) Final() bool {
+	return s.State == SweepCompleted || s.State == SweepFailed
+}
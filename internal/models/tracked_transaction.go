@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+const (
+	TrackedTransactionPending   = "pending"
+	TrackedTransactionMined     = "mined"
+	TrackedTransactionConfirmed = "confirmed"
+	TrackedTransactionReverted  = "reverted"
+	TrackedTransactionDropped   = "dropped"
+	TrackedTransactionReplaced  = "replaced"
+)
+
+// TransactionStatus is the on-chain state of a transaction as reported by a node.
+type TransactionStatus struct {
+	Found       bool
+	Mined       bool
+	Success     bool
+	BlockNumber uint64
+	From        string
+	Nonce       uint64
+}
+
+type TrackedTransaction struct {
+	Network       string                    `json:"network"`
+	Hash          string                    `json:"hash"`
+	State         string                    `json:"state"`
+	Final         bool                      `json:"final"`
+	BlockNumber   uint64                    `json:"block_number,omitempty"`
+	Confirmations uint64                    `json:"confirmations"`
+	From          string                    `json:"from,omitempty"`
+	Nonce         uint64                    `json:"nonce,omitempty"`
+	Replaces      string                    `json:"replaces,omitempty"`
+	ReplacedBy    string                    `json:"replaced_by,omitempty"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+	LastSeenAt    time.Time                 `json:"last_seen_at"`
+	History       []TrackedTransactionEvent `json:"history"`
+}
+
+type TrackedTransactionEvent struct {
+	State       string    `json:"state"`
+	BlockNumber uint64    `json:"block_number,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+type TrackTransactionReq struct {
+	Hash     string `json:"hash" validate:"required"`
+	Replaces string `json:"replaces"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TransferEvent is a token Transfer event observed on chain.
+type TransferEvent struct {
+	Network     string     `json:"network"`
+	Token       string     `json:"token"`
+	Hash        string     `json:"hash"`
+	LogIndex    uint       `json:"log_index"`
+	From        string     `json:"from"`
+	To          string     `json:"to"`
+	Amount      string     `json:"amount"`
+	BlockNumber uint64     `json:"block_number"`
+	BlockTime   *time.Time `json:"block_time,omitempty"`
+}
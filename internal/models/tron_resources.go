@@ -0,0 +1,48 @@
+package models
+
+// TRX amounts are formatted with 6 decimals, resource values are in bandwidth points and energy units.
+type GetTronAccountResourcesResp struct {
+	Address      string                   `json:"address"`
+	Activated    bool                     `json:"activated"`
+	Balance      string                   `json:"balance"`
+	Bandwidth    TronBandwidth            `json:"bandwidth"`
+	Energy       TronEnergy               `json:"energy"`
+	Staked       TronResourceAmounts      `json:"staked"`
+	Unstaking    []TronUnstake            `json:"unstaking"`
+	DelegatedIn  TronResourceAmounts      `json:"delegated_in"`
+	DelegatedOut TronResourceAmounts      `json:"delegated_out"`
+	Delegations  []TronResourceDelegation `json:"delegations"`
+}
+
+type TronBandwidth struct {
+	FreeUsed  int64 `json:"free_used"`
+	FreeLimit int64 `json:"free_limit"`
+	Used      int64 `json:"used"`
+	Limit     int64 `json:"limit"`
+}
+
+type TronEnergy struct {
+	Used  int64 `json:"used"`
+	Limit int64 `json:"limit"`
+}
+
+type TronResourceAmounts struct {
+	Bandwidth string `json:"bandwidth"`
+	Energy    string `json:"energy"`
+	TronPower string `json:"tron_power,omitempty"`
+}
+
+type TronUnstake struct {
+	Resource   string `json:"resource"`
+	Amount     string `json:"amount"`
+	ExpireTime int64  `json:"expire_time"`
+}
+
+type TronResourceDelegation struct {
+	From                string `json:"from"`
+	To                  string `json:"to"`
+	Bandwidth           string `json:"bandwidth"`
+	Energy              string `json:"energy"`
+	BandwidthExpireTime int64  `json:"bandwidth_expire_time,omitempty"`
+	EnergyExpireTime    int64  `json:"energy_expire_time,omitempty"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+type WatchedAddress struct {
+	Network   string            `json:"network"`
+	Address   string            `json:"address"`
+	Tokens    []string          `json:"tokens"`
+	Label     string            `json:"label,omitempty"`
+	OwnerRef  string            `json:"owner_ref,omitempty"`
+	Balances  map[string]string `json:"balances"`
+	CheckedAt *time.Time        `json:"checked_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type BalanceChange struct {
+	Token    string    `json:"token"`
+	Previous string    `json:"previous"`
+	Current  string    `json:"current"`
+	Time     time.Time `json:"time"`
+}
+
+type CreateWatchedAddressReq struct {
+	Network  string   `json:"network" validate:"required"`
+	Address  string   `json:"address" validate:"required"`
+	Tokens   []string `json:"tokens" validate:"required,min=1,dive,oneof=USDT"`
+	Label    string   `json:"label"`
+	OwnerRef string   `json:"owner_ref"`
+}
+
+type UpdateWatchedAddressReq struct {
+	Tokens   []string `json:"tokens" validate:"required,min=1,dive,oneof=USDT"`
+	Label    string   `json:"label"`
+	OwnerRef string   `json:"owner_ref"`
+}
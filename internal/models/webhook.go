@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+const (
+	WebhookEventTransferIncoming = "transfer.incoming"
+	WebhookEventTransferOutgoing = "transfer.outgoing"
+
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type WebhookSubscription struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Addresses []string  `json:"addresses"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateWebhookSubscriptionReq struct {
+	URL       string   `json:"url" validate:"required,url"`
+	Addresses []string `json:"addresses" validate:"required,min=1,dive,required"`
+}
+
+// WebhookEvent is the JSON body POSTed to subscribers.
+type WebhookEvent struct {
+	ID        string        `json:"id"`
+	Type      string        `json:"type"`
+	Address   string        `json:"address"`
+	CreatedAt time.Time     `json:"created_at"`
+	Data      TransferEvent `json:"data"`
+}
+
+type WebhookDelivery struct {
+	ID             string       `json:"id"`
+	SubscriptionID string       `json:"subscription_id"`
+	Event          WebhookEvent `json:"event"`
+	Status         string       `json:"status"`
+	Attempts       int          `json:"attempts"`
+	LastStatusCode int          `json:"last_status_code,omitempty"`
+	LastError      string       `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time    `json:"next_attempt_at"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// AlertChannel delivers balance alerts to operators.
+type AlertChannel interface {
+	Name() string
+	Send(ctx context.Context, alert models.BalanceAlert) (err error)
+}
+
+// newAlertChannels builds the alert channels enabled in the config.
+func (s *Service) newAlertChannels() (channels []AlertChannel) {
+	cfg := s.Config.Service.Alerts.Channels
+	if cfg.Log {
+		channels = append(channels, logAlertChannel{})
+	}
+	if cfg.Webhook.URL != "" {
+		channels = append(channels, webhookAlertChannel{service: s})
+	}
+	if len(cfg.Email.To) > 0 {
+		channels = append(channels, emailAlertChannel{service: s})
+	}
+	return
+}
+
+// RunBalanceAlerts checks the operational wallets against their thresholds
+// every check interval until ctx is done.
+func (s *Service) RunBalanceAlerts(ctx context.Context) {
+	slog.Info("starting balance alerts checker...")
+	ticker := time.NewTicker(time.Duration(s.Config.Service.Alerts.CheckInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("balance alerts checker stopped")
+			return
+		case <-ticker.C:
+			for _, wallet := range s.Config.Service.Alerts.Wallets {
+				s.checkAlertWallet(backgroundContext(ctx), wallet)
+			}
+		}
+	}
+}
+
+func (s *Service) checkAlertWallet(ctx context.Context, wallet config.AlertWallet) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.checkAlertWallet()"),
+		slog.String("address", wallet.Address),
+	)
+
+	network, err := utils.DetectNetworkByName(wallet.Network)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("network", wallet.Network))
+		return
+	}
+	address := utils.NormalizeAddress(network, wallet.Address)
+
+	for token, threshold := range wallet.Thresholds {
+		var balance string
+		if token == nativeToken(network) {
+			balance, err = s.getNativeBalance(ctx, network, wallet.Address)
+		} else {
+			balance, err = s.getTokenBalance(ctx, network, wallet.Address, token)
+		}
+		if err != nil {
+			continue
+		}
+
+		cmp, err := utils.CompareCurrency(balance, threshold)
+		if err != nil {
+			logger.Warn("invalid balance threshold", slog.String("token", token), slog.Any("error", err))
+			continue
+		}
+
+		alert := models.BalanceAlert{
+			Network:   network,
+			Address:   wallet.Address,
+			Label:     wallet.Label,
+			Token:     token,
+			Balance:   balance,
+			Threshold: threshold,
+			Time:      time.Now().UTC(),
+		}
+
+		// the active alerts set makes sure each transition is notified once across replicas
+		if cmp < 0 {
+			alert.Type = models.BalanceAlertLow
+			activated, err := s.Alerts.ActivateBalanceAlert(ctx, network, address, token)
+			if err != nil || !activated {
+				continue
+			}
+			if !s.sendBalanceAlert(ctx, alert) {
+				_, _ = s.Alerts.ResolveBalanceAlert(ctx, network, address, token)
+			}
+		} else {
+			alert.Type = models.BalanceAlertRecovered
+			resolved, err := s.Alerts.ResolveBalanceAlert(ctx, network, address, token)
+			if err != nil || !resolved {
+				continue
+			}
+			if !s.sendBalanceAlert(ctx, alert) {
+				_, _ = s.Alerts.ActivateBalanceAlert(ctx, network, address, token)
+			}
+		}
+	}
+}
+
+// sendBalanceAlert sends the alert to every channel and reports whether at least one delivered it,
+// otherwise the alert state is rolled back to retry on the next check.
+func (s *Service) sendBalanceAlert(ctx context.Context, alert models.BalanceAlert) (sent bool) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.sendBalanceAlert()"),
+		slog.String("type", alert.Type),
+		slog.String("address", alert.Address),
+		slog.String("token", alert.Token),
+	)
+
+	if len(s.alertChannels) == 0 {
+		logger.Warn("no alert channels configured")
+		return true
+	}
+
+	for _, channel := range s.alertChannels {
+		err := channel.Send(ctx, alert)
+		if err != nil {
+			logger.Warn("failed to send balance alert", slog.String("channel", channel.Name()), slog.Any("error", err))
+			continue
+		}
+		sent = true
+	}
+	return
+}
+
+func balanceAlertText(alert models.BalanceAlert) (subject, body string) {
+	name := alert.Address
+	if alert.Label != "" {
+		name = alert.Label + " (" + alert.Address + ")"
+	}
+
+	switch alert.Type {
+	case models.BalanceAlertLow:
+		subject = fmt.Sprintf("Low %s balance on %s", alert.Token, name)
+		body = fmt.Sprintf("%s balance of %s on %s is %s, below the threshold of %s.",
+			alert.Token, name, alert.Network, alert.Balance, alert.Threshold)
+	default:
+		subject = fmt.Sprintf("%s balance recovered on %s", alert.Token, name)
+		body = fmt.Sprintf("%s balance of %s on %s is %s, back above the threshold of %s.",
+			alert.Token, name, alert.Network, alert.Balance, alert.Threshold)
+	}
+	return
+}
+
+type logAlertChannel struct{}
+
+func (logAlertChannel) Name() string {
+	return "log"
+}
+
+func (logAlertChannel) Send(ctx context.Context, alert models.BalanceAlert) (err error) {
+	_, body := balanceAlertText(alert)
+	slog.Warn(body,
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("alert", alert.Type),
+		slog.String("network", alert.Network),
+		slog.String("address", alert.Address),
+		slog.String("token", alert.Token),
+	)
+	return
+}
+
+type webhookAlertChannel struct {
+	service *Service
+}
+
+func (webhookAlertChannel) Name() string {
+	return "webhook"
+}
+
+func (c webhookAlertChannel) Send(ctx context.Context, alert models.BalanceAlert) (err error) {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return
+	}
+
+	cfg := c.service.Config.Service.Alerts.Channels.Webhook
+	statusCode, err := c.service.External.Webhook.Send(ctx, cfg.URL, cfg.Secret, uuid.New().String(), body)
+	if err != nil {
+		return
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		err = fmt.Errorf("unexpected webhook response status %d", statusCode)
+		return
+	}
+	return
+}
+
+type emailAlertChannel struct {
+	service *Service
+}
+
+func (emailAlertChannel) Name() string {
+	return "email"
+}
+
+func (c emailAlertChannel) Send(ctx context.Context, alert models.BalanceAlert) (err error) {
+	subject, body := balanceAlertText(alert)
+	return c.service.External.SMTP.Send(ctx, c.service.Config.Service.Alerts.Channels.Email.To, subject, body)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+)
+
+// RunBalanceInvalidator drops the cached balances of the sender and the recipient of every
+// USDT transfer seen on chain, so deposits show up before the balance TTL expires. Ethereum
+// transfers are read from the logs of each new block and of each block removed by a reorganisation,
+// whose balances were read with its transfers applied. Tron transfers come from the block poller.
+// It runs until both of them stop.
+func (s *Service) RunBalanceInvalidator(ctx context.Context) {
+	slog.Info("starting balance cache invalidator...")
+	defer slog.Info("balance cache invalidator stopped")
+
+	// the channels are read until closed, so the follower and the poller are never held back
+	blocks, transfers := s.ethereumBlocks, s.tronTransfers
+	for blocks != nil || transfers != nil {
+		select {
+		case block, ok := <-blocks:
+			if !ok {
+				blocks = nil
+				continue
+			}
+			if ctx.Err() == nil {
+				s.invalidateBlockBalances(backgroundContext(ctx), block)
+			}
+		case transfer, ok := <-transfers:
+			if !ok {
+				transfers = nil
+				continue
+			}
+			s.invalidateTransferBalances(backgroundContext(ctx), transfer)
+		}
+	}
+}
+
+func (s *Service) invalidateBlockBalances(ctx context.Context, block models.BlockEvent) {
+	// the removed block is looked up by its hash, its number belongs to the new chain already
+	transfers, err := s.External.Ethereum.GetBlockTokenTransfers(ctx, "USDT", block.Hash)
+	if err != nil {
+		return
+	}
+
+	for _, transfer := range transfers {
+		s.invalidateTransferBalances(ctx, transfer)
+	}
+}
+
+func (s *Service) invalidateTransferBalances(ctx context.Context, transfer models.TransferEvent) {
+	contract, err := s.tokenContract(transfer.Network, transfer.Token)
+	if err != nil {
+		return
+	}
+
+	for _, address := range []string{transfer.From, transfer.To} {
+		// balances read before the block of the transfer are not cached by lookups in flight
+		_ = s.Cache.InvalidateWalletBalance(ctx, transfer.Network, contract, utils.NormalizeAddress(transfer.Network, address), transfer.BlockNumber)
+	}
+	slog.Debug("wallet balances invalidated by transfer",
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("network", transfer.Network),
+		slog.String("hash", transfer.Hash),
+	)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+)
+
+func (s *Service) GetBlacklistStatus(ctx context.Context, networkName, address string) (resp models.GetBlacklistStatusResp, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.GetBlacklistStatus()"),
+		slog.String("address", address),
+	)
+
+	network, err := detectAddressNetwork(networkName, address)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("network", networkName))
+		return
+	}
+
+	frozen, err := s.isBlacklisted(ctx, network, address, "USDT")
+	if err != nil {
+		return
+	}
+
+	resp = models.GetBlacklistStatusResp{
+		Network: network,
+		Address: address,
+		Token:   "USDT",
+		Frozen:  frozen,
+	}
+	return
+}
+
+// isBlacklisted checks whether the token issuer froze the address. Results are cached for a short time,
+// since a freeze can happen at any moment.
+func (s *Service) isBlacklisted(ctx context.Context, network, address, token string) (blacklisted bool, err error) {
+	normalized := utils.NormalizeAddress(network, address)
+	blacklisted, found, err := s.Blacklist.GetBlacklistStatus(ctx, network, normalized, token)
+	if found {
+		return
+	}
+
+	switch network {
+	case "ERC20":
+		blacklisted, err = s.External.Ethereum.IsBlacklisted(ctx, address, token)
+	case "TRC20":
+		blacklisted, err = s.External.Tron.IsBlacklisted(ctx, address, token)
+	default:
+		err = ErrUnsupportedNetwork
+	}
+	if err != nil {
+		return
+	}
+
+	_ = s.Blacklist.SaveBlacklistStatus(ctx, network, normalized, token, blacklisted)
+	return
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+// isCacheControlled reports whether the client set its own freshness requirement, which
+// then replaces the cache TTLs and disables serving stale values.
+func isCacheControlled(cacheControl models.CacheControl) bool {
+	return cacheControl.NoCache || cacheControl.MaxAge != nil
+}
+
+// isCacheAllowed reports whether a value cached at cachedAt satisfies the client. Values
+// of unknown age never satisfy a max age.
+func isCacheAllowed(cacheControl models.CacheControl, cachedAt time.Time) bool {
+	switch {
+	case cacheControl.NoCache:
+		return false
+	case cacheControl.MaxAge != nil:
+		return !cachedAt.IsZero() && time.Since(cachedAt) <= *cacheControl.MaxAge
+	}
+	return true
+}
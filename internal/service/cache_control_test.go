@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+func TestIsCacheAllowed(t *testing.T) {
+	maxAge := func(d time.Duration) *time.Duration { return &d }
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		cacheControl   models.CacheControl
+		cachedAt       time.Time
+		wantControlled bool
+		wantAllowed    bool
+	}{
+		{name: "no directives", cachedAt: now.Add(-time.Hour), wantAllowed: true},
+		{name: "no directives and unknown age", wantAllowed: true},
+		{name: "no-cache", cacheControl: models.CacheControl{NoCache: true}, cachedAt: now, wantControlled: true},
+		{name: "no-cache wins over max-age", cacheControl: models.CacheControl{NoCache: true, MaxAge: maxAge(time.Hour)}, cachedAt: now, wantControlled: true},
+		{name: "fresh enough", cacheControl: models.CacheControl{MaxAge: maxAge(time.Minute)}, cachedAt: now.Add(-30 * time.Second), wantControlled: true, wantAllowed: true},
+		{name: "too old", cacheControl: models.CacheControl{MaxAge: maxAge(time.Minute)}, cachedAt: now.Add(-2 * time.Minute), wantControlled: true},
+		{name: "unknown age", cacheControl: models.CacheControl{MaxAge: maxAge(time.Hour)}, wantControlled: true},
+		{name: "zero max-age", cacheControl: models.CacheControl{MaxAge: maxAge(0)}, cachedAt: now.Add(-time.Second), wantControlled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCacheControlled(tt.cacheControl); got != tt.wantControlled {
+				t.Errorf("isCacheControlled() = %v, want %v", got, tt.wantControlled)
+			}
+			if got := isCacheAllowed(tt.cacheControl, tt.cachedAt); got != tt.wantAllowed {
+				t.Errorf("isCacheAllowed() = %v, want %v", got, tt.wantAllowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// coalesce shares one upstream call between concurrent lookups of the same key. Within the replica
+// callers wait for the in-flight call, across replicas a short Redis lock lets one replica fetch while
+// the others wait for the value to appear in the cache. fetch is expected to save the value to the cache.
+// Lock failures are not fatal, the value is then fetched directly. Each caller stops waiting when its
+// own ctx is done, while the shared call keeps running for the others.
+func coalesce[T any](ctx context.Context, s *Service, key string, cached func(ctx context.Context) (T, bool, error), fetch func(ctx context.Context) (T, error)) (value T, err error) {
+	results := s.lookups.DoChan(key, func() (any, error) {
+		// the call is shared, so it must not fail when the request that started it is canceled
+		callCtx := context.WithoutCancel(ctx)
+		cfg := s.Config.Service.Coalescing
+		lockTTL := time.Duration(cfg.LockTTL) * time.Second
+
+		token, locked, err := s.Locks.AcquireLock(callCtx, key, lockTTL)
+		if err != nil {
+			return fetch(callCtx)
+		}
+		if locked {
+			defer s.Locks.ReleaseLock(callCtx, key, token)
+			return fetch(callCtx)
+		}
+
+		// another replica is fetching the value
+		timer := time.NewTimer(time.Duration(cfg.LockPollIntervalMs) * time.Millisecond)
+		defer timer.Stop()
+		for deadline := time.Now().Add(lockTTL); time.Now().Before(deadline); {
+			<-timer.C
+			value, found, err := cached(callCtx)
+			if err == nil && found {
+				return value, nil
+			}
+			timer.Reset(time.Duration(cfg.LockPollIntervalMs) * time.Millisecond)
+		}
+		return fetch(callCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return value, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return value, result.Err
+		}
+		return result.Val.(T), nil
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import "github.com/OwodDEV/crypto-service/internal/models"
+
+var (
+	ErrUnsupportedNetwork         = models.NewError(models.ErrInvalidArgument, "unsupported_network", "unsupported network")
+	ErrAddressNetworkMismatch     = models.NewError(models.ErrInvalidArgument, "address_network_mismatch", "address does not belong to the network")
+	ErrHashNetworkMismatch        = models.NewError(models.ErrInvalidArgument, "hash_network_mismatch", "transaction hash does not belong to the network")
+	ErrNotTronAddress             = models.NewError(models.ErrInvalidArgument, "not_tron_address", "not a Tron address")
+	ErrTooManyBatchItems          = models.NewError(models.ErrInvalidArgument, "too_many_items", "too many items in the batch")
+	ErrInvalidInvoiceAmount       = models.NewError(models.ErrInvalidArgument, "invalid_amount", "invoice amount must be positive")
+	ErrInvoiceNotFound            = models.NewError(models.ErrNotFound, "invoice_not_found", "invoice not found")
+	ErrWebhookNotFound            = models.NewError(models.ErrNotFound, "webhook_subscription_not_found", "webhook subscription not found")
+	ErrWatchedAddressNotFound     = models.NewError(models.ErrNotFound, "watched_address_not_found", "watched address not found")
+	ErrTrackedTransactionNotFound = models.NewError(models.ErrNotFound, "tracked_transaction_not_found", "tracked transaction not found")
+	ErrScreeningEntryNotFound     = models.NewError(models.ErrNotFound, "screening_entry_not_found", "address is not in the internal screening list")
+	ErrAddressAlreadyWatched      = models.NewError(models.ErrUnprocessable, "address_already_watched", "address is already watched")
+	ErrNoFreeReceivingAddress     = models.NewError(models.ErrUnavailable, "no_free_receiving_address", "no free receiving address")
+)
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+// publishEvent wraps the payload into a versioned event and sends it to the event bus when it is enabled.
+// The event ID is built from the network, the key identifying the occurrence and the event type, so
+// consumers can deduplicate events published again for replayed blocks or by another replica.
+func (s *Service) publishEvent(ctx context.Context, eventType, network, key string, data any) {
+	if !s.Config.Service.Events.Enabled {
+		return
+	}
+
+	_ = s.Events.PublishEvent(ctx, models.Event{
+		SchemaVersion: models.EventSchemaVersion,
+		ID:            network + ":" + key + ":" + eventType,
+		Type:          eventType,
+		Network:       network,
+		OccurredAt:    time.Now().UTC(),
+		Data:          data,
+	})
+}
+
+// publishTransferEvent is a TransferHandler publishing transfer.detected events. Publishing is best effort.
+func (s *Service) publishTransferEvent(ctx context.Context, transfer models.TransferEvent) (err error) {
+	key := fmt.Sprintf("%s:%d", transfer.Hash, transfer.LogIndex)
+	s.publishEvent(ctx, models.EventTransferDetected, transfer.Network, key, transfer)
+	return
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+// GetHealth reports the service as degraded while Redis is unavailable. Requests are
+// still served then, bypassing the cache.
+func (s *Service) GetHealth(ctx context.Context) (resp models.HealthResp) {
+	resp.Status = models.HealthStatusOK
+	resp.Cache = s.Health.Status()
+	if resp.Cache.Status == models.ComponentStatusDown {
+		resp.Status = models.HealthStatusDegraded
+	}
+	return
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) CreateInvoice(ctx context.Context, req models.CreateInvoiceReq) (resp models.Invoice, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.CreateInvoice()"),
+	)
+
+	network, err := utils.DetectNetworkByName(req.Network)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("network", req.Network))
+		return
+	}
+
+	cmp, err := utils.CompareCurrency(req.Amount, "0")
+	if err != nil {
+		logger.Warn(err.Error())
+		return
+	}
+	if cmp <= 0 {
+		err = ErrInvalidInvoiceAmount
+		logger.Warn(err.Error(), slog.String("amount", req.Amount))
+		return
+	}
+
+	expiresIn := req.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = s.Config.Service.Invoices.DefaultExpiry
+	}
+	now := time.Now().UTC()
+	expiresAt := now.Add(time.Duration(expiresIn) * time.Second)
+	// payments mined before the expiry are matched only once they are confirmed
+	settleAt := expiresAt.Add(time.Duration(s.Config.Service.Invoices.PaymentGrace) * time.Second)
+
+	// assign a receiving address not held by another open invoice
+	id := uuid.New().String()
+	var address string
+	pool := s.invoiceAddresses(network)
+	for _, i := range rand.Perm(len(pool)) {
+		ok, err := s.Invoices.ReserveInvoiceAddress(ctx, network, utils.NormalizeAddress(network, pool[i]), id, time.Until(settleAt))
+		if err != nil {
+			return resp, err
+		}
+		if ok {
+			address = pool[i]
+			break
+		}
+	}
+	if address == "" {
+		err = ErrNoFreeReceivingAddress
+		logger.Warn(err.Error(), slog.String("network", network))
+		return
+	}
+
+	resp = models.Invoice{
+		ID:        id,
+		Network:   network,
+		Token:     req.Token,
+		Address:   address,
+		Amount:    req.Amount,
+		Received:  "0",
+		Status:    models.InvoicePending,
+		Payments:  []models.InvoicePayment{},
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = s.Invoices.CreateInvoice(ctx, resp, settleAt)
+	if err != nil {
+		return
+	}
+
+	logger.Info("invoice created", slog.String("invoice_id", id), slog.String("address", address))
+	return
+}
+
+func (s *Service) GetInvoice(ctx context.Context, id string) (resp models.Invoice, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.GetInvoice()"),
+		slog.String("invoice_id", id),
+	)
+
+	resp, err = s.Invoices.GetInvoice(ctx, id)
+	if err != nil {
+		return
+	}
+	if resp.ID == "" {
+		err = ErrInvoiceNotFound
+		logger.Warn(err.Error())
+		return
+	}
+	return
+}
+
+// matchInvoicePayment is a TransferHandler crediting transfers to the invoice holding the recipient address.
+// Transfers mined before the invoice was created, which belong to an earlier invoice of the pooled
+// address, or after it expired are not credited.
+func (s *Service) matchInvoicePayment(ctx context.Context, transfer models.TransferEvent) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.matchInvoicePayment()"),
+		slog.String("hash", transfer.Hash),
+	)
+
+	id, err := s.Invoices.GetInvoiceIDByAddress(ctx, transfer.Network, utils.NormalizeAddress(transfer.Network, transfer.To))
+	if err != nil || id == "" {
+		return
+	}
+
+	minedAt, err := s.transferTime(ctx, transfer)
+	if err != nil {
+		return
+	}
+
+	// the invoice is changed atomically, other transfers and replicas may update it concurrently
+	now := time.Now().UTC()
+	invoice, updated, err := s.Invoices.UpdateInvoice(ctx, id, func(invoice *models.Invoice) bool {
+		if invoice.Token != transfer.Token || invoice.Status == models.InvoiceExpired {
+			return false
+		}
+		// block times have second precision
+		if minedAt.Before(invoice.CreatedAt.Truncate(time.Second)) {
+			logger.Warn("payment mined before the invoice was created", slog.String("invoice_id", invoice.ID))
+			return false
+		}
+		if minedAt.After(invoice.ExpiresAt) {
+			logger.Warn("payment mined after the invoice expired", slog.String("invoice_id", invoice.ID))
+			return false
+		}
+		for _, payment := range invoice.Payments {
+			if payment.Hash == transfer.Hash && payment.LogIndex == transfer.LogIndex {
+				return false
+			}
+		}
+
+		received, err := utils.AddCurrency(invoice.Received, transfer.Amount)
+		if err != nil {
+			logger.Error("failed to add payment amount", slog.Any("error", err))
+			return false
+		}
+		cmp, err := utils.CompareCurrency(received, invoice.Amount)
+		if err != nil {
+			logger.Error("failed to compare payment amount", slog.Any("error", err))
+			return false
+		}
+
+		invoice.Received = received
+		invoice.UpdatedAt = now
+		invoice.Payments = append(invoice.Payments, models.InvoicePayment{
+			Hash:        transfer.Hash,
+			LogIndex:    transfer.LogIndex,
+			From:        transfer.From,
+			Amount:      transfer.Amount,
+			BlockNumber: transfer.BlockNumber,
+			Time:        now,
+		})
+		switch {
+		case cmp < 0:
+			invoice.Status = models.InvoiceUnderpaid
+		case cmp == 0:
+			invoice.Status = models.InvoicePaid
+		default:
+			invoice.Status = models.InvoiceOverpaid
+		}
+		return true
+	})
+	if err != nil || !updated {
+		return
+	}
+
+	logger.Info("invoice payment received",
+		slog.String("invoice_id", invoice.ID),
+		slog.String("amount", transfer.Amount),
+		slog.String("status", invoice.Status),
+	)
+	return
+}
+
+// transferTime returns the time the block of the transfer was produced.
+func (s *Service) transferTime(ctx context.Context, transfer models.TransferEvent) (minedAt time.Time, err error) {
+	if transfer.BlockTime != nil {
+		return *transfer.BlockTime, nil
+	}
+	if transfer.Network != "ERC20" {
+		err = ErrUnsupportedNetwork
+		return
+	}
+	return s.External.Ethereum.GetBlockTime(ctx, transfer.BlockNumber)
+}
+
+// RunInvoiceExpirer marks unpaid invoices as expired once their payment window is over until ctx is done.
+func (s *Service) RunInvoiceExpirer(ctx context.Context) {
+	slog.Info("starting invoice expirer...")
+	ticker := time.NewTicker(time.Duration(s.Config.Service.Invoices.CheckInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("invoice expirer stopped")
+			return
+		case <-ticker.C:
+			s.expireInvoices(backgroundContext(ctx))
+		}
+	}
+}
+
+func (s *Service) expireInvoices(ctx context.Context) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.expireInvoices()"),
+	)
+
+	ids, err := s.Invoices.PopSettledInvoices(ctx, time.Now())
+	if err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		_, updated, err := s.Invoices.UpdateInvoice(ctx, id, func(invoice *models.Invoice) bool {
+			if invoice.Status != models.InvoicePending {
+				return false
+			}
+			invoice.Status = models.InvoiceExpired
+			invoice.UpdatedAt = time.Now().UTC()
+			return true
+		})
+		if err != nil || !updated {
+			continue
+		}
+		logger.Info("invoice expired", slog.String("invoice_id", id))
+	}
+}
+
+func (s *Service) invoiceAddresses(network string) []string {
+	switch network {
+	case "ERC20":
+		return s.Config.Service.Invoices.Addresses.Ethereum
+	case "TRC20":
+		return s.Config.Service.Invoices.Addresses.Tron
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+// fakeInvoices holds a single invoice for the pooled address of every transfer.
+type fakeInvoices struct {
+	InvoiceStorage
+	invoice models.Invoice
+}
+
+func (f *fakeInvoices) GetInvoiceIDByAddress(ctx context.Context, network, address string) (invoiceID string, err error) {
+	return f.invoice.ID, nil
+}
+
+func (f *fakeInvoices) UpdateInvoice(ctx context.Context, id string, update func(invoice *models.Invoice) (changed bool)) (invoice models.Invoice, updated bool, err error) {
+	invoice = f.invoice
+	invoice.Payments = append([]models.InvoicePayment(nil), f.invoice.Payments...)
+	if !update(&invoice) {
+		return f.invoice, false, nil
+	}
+	f.invoice = invoice
+	return invoice, true, nil
+}
+
+func TestMatchInvoicePayment(t *testing.T) {
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 500_000_000, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		status       string
+		received     string
+		payments     []models.InvoicePayment
+		token        string
+		amount       string
+		minedAt      time.Time
+		wantStatus   string
+		wantReceived string
+	}{
+		{name: "exact payment", minedAt: createdAt.Add(time.Minute), amount: "10", wantStatus: models.InvoicePaid, wantReceived: "10"},
+		{name: "partial payment", minedAt: createdAt.Add(time.Minute), amount: "4", wantStatus: models.InvoiceUnderpaid, wantReceived: "4"},
+		{name: "overpayment", minedAt: createdAt.Add(time.Minute), amount: "12.5", wantStatus: models.InvoiceOverpaid, wantReceived: "12.5"},
+		{
+			name: "completes an underpaid invoice", status: models.InvoiceUnderpaid, received: "4",
+			payments: []models.InvoicePayment{{Hash: "0xearlier", Amount: "4"}},
+			minedAt:  createdAt.Add(time.Minute), amount: "6", wantStatus: models.InvoicePaid, wantReceived: "10",
+		},
+		{name: "mined in the second the invoice was created", minedAt: createdAt.Truncate(time.Second), amount: "10", wantStatus: models.InvoicePaid, wantReceived: "10"},
+		{name: "mined before the invoice was created", minedAt: createdAt.Add(-time.Second), amount: "10", wantStatus: models.InvoicePending, wantReceived: "0"},
+		{name: "mined when the invoice expires", minedAt: expiresAt, amount: "10", wantStatus: models.InvoicePaid, wantReceived: "10"},
+		{name: "mined after the invoice expired", minedAt: expiresAt.Add(time.Second), amount: "10", wantStatus: models.InvoicePending, wantReceived: "0"},
+		{name: "expired invoice", status: models.InvoiceExpired, minedAt: createdAt.Add(time.Minute), amount: "10", wantStatus: models.InvoiceExpired, wantReceived: "0"},
+		{name: "other token", token: "USDC", minedAt: createdAt.Add(time.Minute), amount: "10", wantStatus: models.InvoicePending, wantReceived: "0"},
+		{
+			name: "payment already credited", status: models.InvoiceUnderpaid, received: "4",
+			payments: []models.InvoicePayment{{Hash: "0xpayment", Amount: "4"}},
+			minedAt:  createdAt.Add(time.Minute), amount: "4", wantStatus: models.InvoiceUnderpaid, wantReceived: "4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoices := &fakeInvoices{invoice: models.Invoice{
+				ID:        "invoice",
+				Network:   "ERC20",
+				Token:     "USDT",
+				Address:   "0xpool",
+				Amount:    "10",
+				Received:  "0",
+				Status:    models.InvoicePending,
+				Payments:  tt.payments,
+				ExpiresAt: expiresAt,
+				CreatedAt: createdAt,
+			}}
+			if tt.status != "" {
+				invoices.invoice.Status = tt.status
+			}
+			if tt.received != "" {
+				invoices.invoice.Received = tt.received
+			}
+			token := "USDT"
+			if tt.token != "" {
+				token = tt.token
+			}
+			s := &Service{Invoices: invoices}
+
+			ctx := context.WithValue(context.Background(), "request_id", "test")
+			err := s.matchInvoicePayment(ctx, models.TransferEvent{
+				Network:     "ERC20",
+				Token:       token,
+				Hash:        "0xpayment",
+				To:          "0xPool",
+				Amount:      tt.amount,
+				BlockNumber: 100,
+				BlockTime:   &tt.minedAt,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if invoices.invoice.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", invoices.invoice.Status, tt.wantStatus)
+			}
+			if invoices.invoice.Received != tt.wantReceived {
+				t.Errorf("received = %q, want %q", invoices.invoice.Received, tt.wantReceived)
+			}
+		})
+	}
+}
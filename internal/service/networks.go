@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/external/ethereum"
+	"github.com/OwodDEV/crypto-service/internal/external/tron"
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+func (s *Service) requiredConfirmations(network string) uint64 {
+	switch network {
+	case "ERC20":
+		return s.Config.External.Ethereum.Confirmations
+	case "TRC20":
+		return s.Config.External.Tron.Confirmations
+	}
+	return 0
+}
+
+func (s *Service) getBlockNumber(ctx context.Context, network string) (number uint64, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.GetBlockNumber(ctx)
+	case "TRC20":
+		return s.External.Tron.GetBlockNumber(ctx)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+func (s *Service) getTransactionStatus(ctx context.Context, network, hash string) (status models.TransactionStatus, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.GetTransactionStatus(ctx, hash)
+	case "TRC20":
+		return s.External.Tron.GetTransactionStatus(ctx, hash)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+func (s *Service) getTokenBalance(ctx context.Context, network, address, token string) (balance string, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.GetBalance(ctx, address, token)
+	case "TRC20":
+		return s.External.Tron.GetBalance(ctx, address, token)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+// getTokenBalanceAtHead reads the balance together with the block it reflects. Ethereum balances are read
+// at that block, Tron ones at the latest block, which is at or after it. The block is optional: it is
+// left 0 and the balance is read at the latest block when the block number is unavailable.
+func (s *Service) getTokenBalanceAtHead(ctx context.Context, network, address, token string) (balance string, blockNumber uint64, err error) {
+	blockNumber, err = s.getBlockNumber(ctx, network)
+	if err != nil {
+		slog.Warn("balance block number is unavailable",
+			slog.String("request_id", ctx.Value("request_id").(string)),
+			slog.String("network", network),
+			slog.Any("error", err),
+		)
+		blockNumber = 0
+	}
+
+	if network == "ERC20" && blockNumber != 0 {
+		balance, err = s.External.Ethereum.GetBalanceAtBlock(ctx, address, token, blockNumber)
+		return
+	}
+	balance, err = s.getTokenBalance(ctx, network, address, token)
+	return
+}
+
+func (s *Service) getNativeBalance(ctx context.Context, network, address string) (balance string, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.GetNativeBalance(ctx, address)
+	case "TRC20":
+		return s.External.Tron.GetNativeBalance(ctx, address)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+func (s *Service) addressFromKey(network, key string) (address string, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.AddressFromKey(key)
+	case "TRC20":
+		return s.External.Tron.AddressFromKey(key)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+func (s *Service) signNativeTransfer(ctx context.Context, network, key, to, amount string) (signed models.SignedTransaction, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.SignNativeTransfer(ctx, key, to, amount)
+	case "TRC20":
+		return s.External.Tron.SignNativeTransfer(ctx, key, to, amount)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+func (s *Service) signTokenTransfer(ctx context.Context, network, key, to, token, amount string) (signed models.SignedTransaction, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.SignTokenTransfer(ctx, key, to, token, amount)
+	case "TRC20":
+		return s.External.Tron.SignTokenTransfer(ctx, key, to, token, amount)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+func (s *Service) broadcastTransaction(ctx context.Context, network string, signed models.SignedTransaction) (err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.BroadcastTransaction(ctx, signed)
+	case "TRC20":
+		return s.External.Tron.BroadcastTransaction(ctx, signed)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+// isTransactionExpired reports whether a broadcast failed because the transaction can never be mined.
+func isTransactionExpired(err error) bool {
+	return errors.Is(err, ethereum.ErrTransactionExpired) || errors.Is(err, tron.ErrTransactionExpired)
+}
+
+func (s *Service) getTokenTransfers(ctx context.Context, network, token string, fromBlock, toBlock uint64) (transfers []models.TransferEvent, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.GetTokenTransfers(ctx, token, fromBlock, toBlock)
+	case "TRC20":
+		return s.External.Tron.GetTokenTransfers(ctx, token, fromBlock, toBlock)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
+
+// nativeToken returns the symbol of the network native coin.
+func nativeToken(network string) string {
+	switch network {
+	case "ERC20":
+		return "ETH"
+	case "TRC20":
+		return "TRX"
+	}
+	return ""
+}
+
+func (s *Service) tokenContract(network, token string) (contract string, err error) {
+	switch network {
+	case "ERC20":
+		return s.External.Ethereum.TokenContract(token)
+	case "TRC20":
+		return s.External.Tron.TokenContract(token)
+	}
+	err = ErrUnsupportedNetwork
+	return
+}
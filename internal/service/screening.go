@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+)
+
+// screeningLists holds the entries of the screening list files, keyed by network and normalized address.
+type screeningLists struct {
+	mu       sync.RWMutex
+	entries  map[string][]models.ScreeningEntry
+	modTimes map[string]time.Time
+}
+
+func screeningKey(network, address string) string {
+	return network + ":" + utils.NormalizeAddress(network, address)
+}
+
+func (s *Service) CreateScreeningEntry(ctx context.Context, req models.CreateScreeningEntryReq) (resp models.ScreeningEntry, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.CreateScreeningEntry()"),
+		slog.String("address", req.Address),
+	)
+
+	network, err := utils.DetectNetworkByAddr(req.Address)
+	if err != nil {
+		logger.Warn(err.Error())
+		return
+	}
+	if req.Network != "" {
+		network, err = detectAddressNetwork(req.Network, req.Address)
+		if err != nil {
+			logger.Warn(err.Error(), slog.String("network", req.Network))
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	resp = models.ScreeningEntry{
+		Network:   network,
+		Address:   req.Address,
+		Source:    models.ScreeningSourceInternal,
+		Reason:    req.Reason,
+		CreatedAt: &now,
+	}
+	err = s.Screening.SaveScreeningEntry(ctx, resp, utils.NormalizeAddress(network, req.Address))
+	if err != nil {
+		return
+	}
+
+	logger.Info("address added to the internal screening list", slog.String("network", network))
+	return
+}
+
+func (s *Service) ListScreeningEntries(ctx context.Context) (resp []models.ScreeningEntry, err error) {
+	return s.Screening.ListScreeningEntries(ctx)
+}
+
+func (s *Service) DeleteScreeningEntry(ctx context.Context, networkName, address string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.DeleteScreeningEntry()"),
+		slog.String("address", address),
+	)
+
+	network, err := detectAddressNetwork(networkName, address)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("network", networkName))
+		return
+	}
+
+	normalized := utils.NormalizeAddress(network, address)
+	entry, err := s.Screening.GetScreeningEntry(ctx, network, normalized)
+	if err != nil {
+		return
+	}
+	if entry.Address == "" {
+		err = ErrScreeningEntryNotFound
+		logger.Warn(err.Error())
+		return
+	}
+	return s.Screening.DeleteScreeningEntry(ctx, network, normalized)
+}
+
+// screenAddress returns the screening list entries matching the address, reported as the given party.
+// incomplete is set when the internal list could not be checked, only the file lists were then used.
+func (s *Service) screenAddress(ctx context.Context, network, address, party string) (matches []models.ScreeningMatch, incomplete bool, err error) {
+	if !s.Config.Service.Screening.Enabled || address == "" {
+		return
+	}
+
+	s.screening.mu.RLock()
+	entries := s.screening.entries[screeningKey(network, address)]
+	s.screening.mu.RUnlock()
+
+	// the file lists are still checked while Redis is unavailable
+	internal, err := s.Screening.GetScreeningEntry(ctx, network, utils.NormalizeAddress(network, address))
+	if err != nil {
+		slog.Error("internal screening list is unavailable, screening is incomplete",
+			slog.String("request_id", ctx.Value("request_id").(string)),
+			slog.String("network", network),
+			slog.String("address", address),
+			slog.Any("error", err),
+		)
+		incomplete, err = true, nil
+	}
+	if internal.Address != "" {
+		entries = append(entries, internal)
+	}
+
+	for _, entry := range entries {
+		matches = append(matches, models.ScreeningMatch{
+			Party:   party,
+			Address: address,
+			Source:  entry.Source,
+			Reason:  entry.Reason,
+		})
+	}
+
+	if len(matches) > 0 {
+		slog.Warn("address matches screening lists",
+			slog.String("request_id", ctx.Value("request_id").(string)),
+			slog.String("network", network),
+			slog.String("address", address),
+			slog.String("party", party),
+			slog.Int("matches", len(matches)),
+		)
+	}
+	return
+}
+
+// RunScreeningReloader reloads the screening list files when they change until ctx is done.
+func (s *Service) RunScreeningReloader(ctx context.Context) {
+	slog.Info("starting screening lists reloader...")
+	ticker := time.NewTicker(time.Duration(s.Config.Service.Screening.ReloadInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("screening lists reloader stopped")
+			return
+		case <-ticker.C:
+			if !s.screeningFilesChanged() {
+				continue
+			}
+
+			// the previous lists stay active until the files load again
+			err := s.loadScreeningFiles()
+			if err != nil {
+				slog.Error("failed to reload screening lists", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+func (s *Service) screeningFilesChanged() bool {
+	s.screening.mu.RLock()
+	defer s.screening.mu.RUnlock()
+
+	for _, path := range s.Config.Service.Screening.Files {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(s.screening.modTimes[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadScreeningFiles reads all configured screening list files and replaces the loaded lists.
+func (s *Service) loadScreeningFiles() (err error) {
+	entries := make(map[string][]models.ScreeningEntry)
+	modTimes := make(map[string]time.Time)
+
+	for _, path := range s.Config.Service.Screening.Files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		fileEntries, err := readScreeningFile(path)
+		if err != nil {
+			return fmt.Errorf("screening list %s: %w", path, err)
+		}
+
+		skipped := 0
+		for _, entry := range fileEntries {
+			if entry.Source == "" {
+				entry.Source = filepath.Base(path)
+			}
+
+			network, err := utils.DetectNetworkByAddr(entry.Address)
+			if entry.Network != "" {
+				network, err = detectAddressNetwork(entry.Network, entry.Address)
+			}
+			if err != nil {
+				skipped++
+				continue
+			}
+			entry.Network = network
+
+			key := screeningKey(network, entry.Address)
+			entries[key] = append(entries[key], entry)
+		}
+		modTimes[path] = info.ModTime()
+
+		slog.Info("loaded screening list",
+			slog.String("file", path),
+			slog.Int("entries", len(fileEntries)-skipped),
+			slog.Int("skipped", skipped),
+		)
+	}
+
+	s.screening.mu.Lock()
+	s.screening.entries = entries
+	s.screening.modTimes = modTimes
+	s.screening.mu.Unlock()
+	return
+}
+
+// readScreeningFile parses a JSON array of entries (or of plain addresses) or a CSV file.
+// CSV files either have a header row with an "address" column and optional "network", "source"
+// and "reason" columns, or hold the address in the first column.
+func readScreeningFile(path string) (entries []models.ScreeningEntry, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &entries)
+		if err == nil {
+			return
+		}
+
+		var addresses []string
+		if json.Unmarshal(data, &addresses) != nil {
+			return nil, err
+		}
+		for _, address := range addresses {
+			entries = append(entries, models.ScreeningEntry{Address: address})
+		}
+		return entries, nil
+	}
+
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return
+	}
+
+	columns := map[string]int{"address": 0, "network": -1, "source": -1, "reason": -1}
+	if len(records) > 0 && containsHeader(records[0]) {
+		for column := range columns {
+			columns[column] = -1
+		}
+		for i, name := range records[0] {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if _, ok := columns[name]; ok {
+				columns[name] = i
+			}
+		}
+		records = records[1:]
+	}
+
+	field := func(record []string, column string) string {
+		i := columns[column]
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	for _, record := range records {
+		address := field(record, "address")
+		if address == "" {
+			continue
+		}
+		entries = append(entries, models.ScreeningEntry{
+			Network: field(record, "network"),
+			Address: address,
+			Source:  field(record, "source"),
+			Reason:  field(record, "reason"),
+		})
+	}
+	return
+}
+
+func containsHeader(record []string) bool {
+	for _, name := range record {
+		if strings.EqualFold(strings.TrimSpace(name), "address") {
+			return true
+		}
+	}
+	return false
+}
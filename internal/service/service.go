@@ -2,29 +2,207 @@ package service
 
 import (
 	"context"
+	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/OwodDEV/crypto-service/internal/config"
 	"github.com/OwodDEV/crypto-service/internal/external"
+	"github.com/OwodDEV/crypto-service/internal/models"
 	"github.com/OwodDEV/crypto-service/internal/storages"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
 )
 
 type Service struct {
 	Config   *config.Config
 	External *external.External
 
-	Cache Cache
+	Cache               Cache
+	Transactions        TransactionCache
+	TrackedTransactions TrackedTransactionStorage
+	Sweeps              SweepStorage
+	TransferWatcher     TransferWatcherStorage
+	Invoices            InvoiceStorage
+	Webhooks            WebhookStorage
+	Watchlist           WatchlistStorage
+	Stream              StreamStorage
+	Alerts              AlertStorage
+	Events              EventBus
+	Blacklist           BlacklistStorage
+	Screening           ScreeningStorage
+	Locks               LockStorage
+	Health              HealthReporter
+
+	transferHandlers []TransferHandler
+	alertChannels    []AlertChannel
+	screening        screeningLists
+	lookups          singleflight.Group
+	refreshing       sync.Map
+	ethereumBlocks   <-chan models.BlockEvent
+	tronTransfers    <-chan models.TransferEvent
 }
 
 type Cache interface {
-	SaveWalletBalance(ctx context.Context, address, balance string) (err error)
-	GetWalletBalance(ctx context.Context, address string) (balance string, err error)
+	SaveWalletBalance(ctx context.Context, network, contract, address string, cached models.CachedBalance) (saved bool, err error)
+	GetWalletBalance(ctx context.Context, network, contract, address string) (cached models.CachedBalance, err error)
+	GetWalletBalances(ctx context.Context, keys []models.WalletBalanceKey) (cached []models.CachedBalance, err error)
+	InvalidateWalletBalance(ctx context.Context, network, contract, address string, blockNumber uint64) (err error)
+}
+
+type TransactionCache interface {
+	SaveTransaction(ctx context.Context, network, hash string, trx models.CachedTransaction, final bool) (err error)
+	GetTransaction(ctx context.Context, network, hash string) (trx models.CachedTransaction, err error)
+}
+
+type TrackedTransactionStorage interface {
+	SaveTrackedTransaction(ctx context.Context, trx models.TrackedTransaction) (err error)
+	GetTrackedTransaction(ctx context.Context, network, hash string) (trx models.TrackedTransaction, err error)
+	ListActiveTrackedTransactions(ctx context.Context) (trxs []models.TrackedTransaction, err error)
+}
+
+type SweepStorage interface {
+	SaveSweep(ctx context.Context, sweep models.Sweep) (err error)
+	GetSweep(ctx context.Context, network, address string) (sweep models.Sweep, err error)
+}
+
+type TransferWatcherStorage interface {
+	SaveTransferWatcherCursor(ctx context.Context, network string, block uint64) (err error)
+	GetTransferWatcherCursor(ctx context.Context, network string) (block uint64, err error)
+}
+
+type InvoiceStorage interface {
+	ReserveInvoiceAddress(ctx context.Context, network, address, invoiceID string, ttl time.Duration) (ok bool, err error)
+	GetInvoiceIDByAddress(ctx context.Context, network, address string) (invoiceID string, err error)
+	CreateInvoice(ctx context.Context, invoice models.Invoice, settleAt time.Time) (err error)
+	UpdateInvoice(ctx context.Context, id string, update func(invoice *models.Invoice) (changed bool)) (invoice models.Invoice, updated bool, err error)
+	GetInvoice(ctx context.Context, id string) (invoice models.Invoice, err error)
+	PopSettledInvoices(ctx context.Context, now time.Time) (ids []string, err error)
+}
+
+type WebhookStorage interface {
+	SaveWebhookSubscription(ctx context.Context, subscription models.WebhookSubscription, addresses []string) (err error)
+	GetWebhookSubscription(ctx context.Context, id string) (subscription models.WebhookSubscription, err error)
+	DeleteWebhookSubscription(ctx context.Context, subscription models.WebhookSubscription, addresses []string) (err error)
+	ListWebhookSubscriptionIDsByAddress(ctx context.Context, address string) (ids []string, err error)
+	CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) (created bool, err error)
+	SaveWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) (err error)
+	GetWebhookDelivery(ctx context.Context, id string) (delivery models.WebhookDelivery, err error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID string) (deliveries []models.WebhookDelivery, err error)
+	LeaseDueWebhookDelivery(ctx context.Context, now, leaseUntil time.Time) (id string, err error)
+	DequeueWebhookDelivery(ctx context.Context, id string) (err error)
+}
+
+type WatchlistStorage interface {
+	CreateWatchedAddress(ctx context.Context, watched models.WatchedAddress, address string) (created bool, err error)
+	GetWatchedAddress(ctx context.Context, network, address string) (watched models.WatchedAddress, err error)
+	UpdateWatchedAddress(ctx context.Context, network, address string, update func(watched *models.WatchedAddress) (changed bool)) (watched models.WatchedAddress, updated bool, err error)
+	ListWatchedAddresses(ctx context.Context) (list []models.WatchedAddress, err error)
+	DeleteWatchedAddress(ctx context.Context, network, address string) (err error)
+	AddBalanceChange(ctx context.Context, network, address string, change models.BalanceChange) (err error)
+	ListBalanceChanges(ctx context.Context, network, address string) (changes []models.BalanceChange, err error)
+}
+
+type StreamStorage interface {
+	PublishStreamEvent(ctx context.Context, event models.StreamEvent) (err error)
+	SubscribeStreamEvents(ctx context.Context) <-chan models.StreamEvent
+}
+
+type AlertStorage interface {
+	ActivateBalanceAlert(ctx context.Context, network, address, token string) (activated bool, err error)
+	ResolveBalanceAlert(ctx context.Context, network, address, token string) (resolved bool, err error)
+}
+
+type BlacklistStorage interface {
+	SaveBlacklistStatus(ctx context.Context, network, address, token string, blacklisted bool) (err error)
+	GetBlacklistStatus(ctx context.Context, network, address, token string) (blacklisted, found bool, err error)
+}
+
+type ScreeningStorage interface {
+	SaveScreeningEntry(ctx context.Context, entry models.ScreeningEntry, address string) (err error)
+	GetScreeningEntry(ctx context.Context, network, address string) (entry models.ScreeningEntry, err error)
+	ListScreeningEntries(ctx context.Context) (entries []models.ScreeningEntry, err error)
+	DeleteScreeningEntry(ctx context.Context, network, address string) (err error)
+}
+
+type LockStorage interface {
+	AcquireLock(ctx context.Context, name string, ttl time.Duration) (token string, ok bool, err error)
+	ReleaseLock(ctx context.Context, name, token string) (err error)
+}
+
+// HealthReporter reports whether a dependency is reachable.
+type HealthReporter interface {
+	Status() models.ComponentStatus
+}
+
+// EventBus publishes events for downstream services.
+type EventBus interface {
+	PublishEvent(ctx context.Context, event models.Event) (err error)
 }
 
 func NewService(external *external.External, storages *storages.Storages, cfg *config.Config) (service *Service, err error) {
 	service = &Service{
-		Config:   cfg,
-		External: external,
-		Cache:    storages.Cache,
+		Config:              cfg,
+		External:            external,
+		Cache:               storages.Cache,
+		Transactions:        storages.Cache,
+		TrackedTransactions: storages.Cache,
+		Sweeps:              storages.Cache,
+		TransferWatcher:     storages.Cache,
+		Invoices:            storages.Cache,
+		Webhooks:            storages.Cache,
+		Watchlist:           storages.Cache,
+		Stream:              storages.Cache,
+		Alerts:              storages.Cache,
+		Events:              storages.Cache,
+		Blacklist:           storages.Cache,
+		Screening:           storages.Cache,
+		Locks:               storages.Cache,
+		Health:              storages.Cache,
 	}
+
+	// hot balances are served from the in-process tier in front of Redis
+	switch {
+	case !cfg.Storages.Cache.Enabled:
+		// the features kept only in Redis are not started, see cmd/crypto-service
+		service.Cache = storages.Noop
+		service.Transactions = storages.Noop
+		service.Blacklist = storages.Noop
+		service.Stream = storages.Noop
+		service.Events = storages.Noop
+		service.Screening = storages.Noop
+		service.Locks = storages.Noop
+		service.Health = storages.Noop
+	case cfg.Storages.Memory.Enabled:
+		service.Cache = storages.Memory
+	}
+
+	if cfg.Service.Screening.Enabled {
+		err = service.loadScreeningFiles()
+		if err != nil {
+			slog.Error("failed to load screening lists", slog.Any("error", err))
+			return
+		}
+	}
+	service.alertChannels = service.newAlertChannels()
+
+	// the follower and the poller start after the service, so the consumers are registered here
+	if cfg.Storages.Cache.Enabled && cfg.Service.BalanceCache.InvalidateOnTransfer {
+		service.ethereumBlocks = external.Ethereum.SubscribeBlocks()
+		service.tronTransfers = external.Tron.SubscribeTransfers()
+		external.Tron.Cursors = storages.Cache
+	}
+
+	service.OnTransfer(service.matchInvoicePayment)
+	service.OnTransfer(service.notifyWebhooks)
+	service.OnTransfer(service.publishTransferUpdate)
+	service.OnTransfer(service.publishTransferEvent)
 	return
 }
+
+// backgroundContext attaches a generated request ID to ctx, so background jobs
+// can call the same code paths as HTTP requests.
+func backgroundContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, "request_id", uuid.New().String())
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+)
+
+// StreamEvents returns balance and transfer updates published by any replica until ctx is done.
+func (s *Service) StreamEvents(ctx context.Context) <-chan models.StreamEvent {
+	return s.Stream.SubscribeStreamEvents(ctx)
+}
+
+// publishTransferUpdate is a TransferHandler streaming transfers to the clients of both parties. Publishing is best effort.
+func (s *Service) publishTransferUpdate(ctx context.Context, transfer models.TransferEvent) (err error) {
+	_ = s.Stream.PublishStreamEvent(ctx, models.StreamEvent{
+		Type:    models.StreamEventTransfer,
+		Network: transfer.Network,
+		Addresses: []string{
+			utils.NormalizeAddress(transfer.Network, transfer.From),
+			utils.NormalizeAddress(transfer.Network, transfer.To),
+		},
+		Token:    transfer.Token,
+		Transfer: &transfer,
+		Time:     time.Now().UTC(),
+	})
+	return
+}
+
+// publishBalanceUpdate streams a freshly read balance. Clients are notified only when it differs from
+// the last value they received.
+func (s *Service) publishBalanceUpdate(ctx context.Context, network, address, token, balance string) {
+	_ = s.Stream.PublishStreamEvent(ctx, models.StreamEvent{
+		Type:      models.StreamEventBalance,
+		Network:   network,
+		Addresses: []string{utils.NormalizeAddress(network, address)},
+		Token:     token,
+		Balance:   balance,
+		Time:      time.Now().UTC(),
+	})
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// sweepLockTTL bounds a single sweep step of an address: the balance lookups, signing and broadcasting.
+const sweepLockTTL = 2 * time.Minute
+
+// RunSweeper periodically consolidates the USDT balances of deposit addresses
+// into the treasury wallets until ctx is done.
+func (s *Service) RunSweeper(ctx context.Context) {
+	slog.Info("starting deposit sweeper...")
+	ticker := time.NewTicker(time.Duration(s.Config.Service.Sweeper.Interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("deposit sweeper stopped")
+			return
+		case <-ticker.C:
+			s.sweepNetwork(backgroundContext(ctx), "ERC20", s.Config.Service.Sweeper.Ethereum)
+			s.sweepNetwork(backgroundContext(ctx), "TRC20", s.Config.Service.Sweeper.Tron)
+		}
+	}
+}
+
+func (s *Service) sweepNetwork(ctx context.Context, network string, cfg config.SweeperNetwork) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.sweepNetwork()"),
+		slog.String("network", network),
+	)
+
+	for _, key := range cfg.DepositKeys {
+		if ctx.Err() != nil {
+			return
+		}
+
+		address, err := s.addressFromKey(network, key)
+		if err != nil {
+			logger.Error("invalid deposit key", slog.Any("error", err))
+			continue
+		}
+
+		err = s.sweepAddress(ctx, network, address, key, cfg)
+		if err != nil {
+			logger.Warn("failed to sweep deposit address", slog.String("address", address), slog.Any("error", err))
+		}
+	}
+}
+
+func (s *Service) sweepAddress(ctx context.Context, network, address, key string, cfg config.SweeperNetwork) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.sweepAddress()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	// every replica runs the sweeper, only one of them may sign for the address at a time
+	lockName := "sweep:" + network + ":" + address
+	token, locked, err := s.Locks.AcquireLock(ctx, lockName, sweepLockTTL)
+	if err != nil {
+		return
+	}
+	if !locked {
+		logger.Debug("address is being swept by another replica")
+		return
+	}
+	defer s.Locks.ReleaseLock(ctx, lockName, token)
+
+	sweep, err := s.Sweeps.GetSweep(ctx, network, address)
+	if err != nil {
+		return
+	}
+	if sweep.ID != "" && !sweep.Final() {
+		return s.resumeSweep(ctx, &sweep, key, cfg)
+	}
+
+	balance, err := s.getTokenBalance(ctx, network, address, "USDT")
+	if err != nil {
+		return
+	}
+	cmp, err := utils.CompareCurrency(balance, cfg.MinAmount)
+	if err != nil || cmp < 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	sweep = models.Sweep{
+		ID:        uuid.New().String(),
+		Network:   network,
+		Address:   address,
+		Amount:    balance,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	logger.Info("starting sweep", slog.String("sweep_id", sweep.ID), slog.String("amount", balance))
+	return s.fundSweep(ctx, &sweep, key, cfg)
+}
+
+// fundSweep sends the sweep transfer when the deposit address can pay its fee,
+// and tops the address up from the gas wallet otherwise.
+func (s *Service) fundSweep(ctx context.Context, sweep *models.Sweep, key string, cfg config.SweeperNetwork) (err error) {
+	nativeBalance, err := s.getNativeBalance(ctx, sweep.Network, sweep.Address)
+	if err != nil {
+		return
+	}
+	cmp, err := utils.CompareCurrency(nativeBalance, cfg.MinNativeBalance)
+	if err != nil {
+		return
+	}
+	if cmp >= 0 {
+		return s.sendSweepTransfer(ctx, sweep, key, cfg)
+	}
+
+	// not enough native coin to pay the transfer fee, top up from the gas wallet first
+	if cfg.GasWalletKey == "" {
+		err = errors.New("gas wallet key is not configured")
+		return
+	}
+	signed, err := s.signNativeTransfer(ctx, sweep.Network, cfg.GasWalletKey, sweep.Address, cfg.TopUpAmount)
+	if err != nil {
+		return
+	}
+	sweep.TopUp = &signed
+	setSweepState(sweep, models.SweepTopUpPending, signed.Hash, "")
+	err = s.Sweeps.SaveSweep(ctx, *sweep)
+	if err != nil {
+		return
+	}
+
+	// a failed broadcast is retried by resumeSweep
+	_ = s.broadcastTransaction(ctx, sweep.Network, signed)
+	return
+}
+
+func (s *Service) sendSweepTransfer(ctx context.Context, sweep *models.Sweep, key string, cfg config.SweeperNetwork) (err error) {
+	signed, err := s.signTokenTransfer(ctx, sweep.Network, key, cfg.TreasuryAddress, "USDT", sweep.Amount)
+	if err != nil {
+		setSweepState(sweep, models.SweepFailed, "", err.Error())
+		return s.Sweeps.SaveSweep(ctx, *sweep)
+	}
+
+	sweep.Transfer = &signed
+	setSweepState(sweep, models.SweepTransferPending, signed.Hash, "")
+	err = s.Sweeps.SaveSweep(ctx, *sweep)
+	if err != nil {
+		return
+	}
+
+	// a failed broadcast is retried by resumeSweep
+	_ = s.broadcastTransaction(ctx, sweep.Network, signed)
+	return
+}
+
+// resumeSweep advances a sweep by checking the transaction of its current step. Transactions unknown
+// to the node are rebroadcast from their stored signed form until they expire, the step is then signed again.
+func (s *Service) resumeSweep(ctx context.Context, sweep *models.Sweep, key string, cfg config.SweeperNetwork) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.resumeSweep()"),
+		slog.String("sweep_id", sweep.ID),
+		slog.String("state", sweep.State),
+	)
+
+	pending := pendingSweepTransaction(*sweep)
+	if pending == nil {
+		setSweepState(sweep, models.SweepFailed, "", "sweep has no pending transaction")
+		return s.Sweeps.SaveSweep(ctx, *sweep)
+	}
+
+	status, err := s.getTransactionStatus(ctx, sweep.Network, pending.Hash)
+	if err != nil {
+		return
+	}
+
+	switch nextSweepStep(sweep.State, status) {
+	case sweepStepFail:
+		setSweepState(sweep, models.SweepFailed, pending.Hash, "transaction reverted")
+		return s.Sweeps.SaveSweep(ctx, *sweep)
+	case sweepStepTransfer:
+		return s.sendSweepTransfer(ctx, sweep, key, cfg)
+	case sweepStepComplete:
+		setSweepState(sweep, models.SweepCompleted, pending.Hash, "")
+		logger.Info("sweep completed", slog.String("amount", sweep.Amount))
+		return s.Sweeps.SaveSweep(ctx, *sweep)
+	case sweepStepWait:
+		// waiting in the pending pool
+		return
+	}
+
+	err = s.broadcastTransaction(ctx, sweep.Network, *pending)
+	if isTransactionExpired(err) {
+		logger.Warn("sweep transaction expired, signing it again", slog.String("hash", pending.Hash))
+		if sweep.State == models.SweepTopUpPending {
+			return s.fundSweep(ctx, sweep, key, cfg)
+		}
+		return s.sendSweepTransfer(ctx, sweep, key, cfg)
+	}
+	// other broadcast failures are retried on the next run
+	return
+}
+
+// Steps of a resumed sweep, decided by the status of its pending transaction.
+const (
+	sweepStepWait        = "wait"
+	sweepStepRebroadcast = "rebroadcast"
+	sweepStepTransfer    = "transfer"
+	sweepStepComplete    = "complete"
+	sweepStepFail        = "fail"
+)
+
+// pendingSweepTransaction returns the transaction the sweep waits for in its current state.
+func pendingSweepTransaction(sweep models.Sweep) *models.SignedTransaction {
+	switch sweep.State {
+	case models.SweepTopUpPending:
+		return sweep.TopUp
+	case models.SweepTransferPending:
+		return sweep.Transfer
+	}
+	return nil
+}
+
+// nextSweepStep decides how a sweep in the given state advances once its pending transaction has the given status.
+func nextSweepStep(state string, status models.TransactionStatus) string {
+	switch {
+	case status.Mined && !status.Success:
+		return sweepStepFail
+	case status.Mined && state == models.SweepTopUpPending:
+		return sweepStepTransfer
+	case status.Mined:
+		return sweepStepComplete
+	case status.Found:
+		return sweepStepWait
+	}
+	return sweepStepRebroadcast
+}
+
+func setSweepState(sweep *models.Sweep, state, hash, errMsg string) {
+	now := time.Now().UTC()
+	sweep.State = state
+	sweep.UpdatedAt = now
+	sweep.Steps = append(sweep.Steps, models.SweepStep{
+		State: state,
+		Hash:  hash,
+		Error: errMsg,
+		Time:  now,
+	})
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+func TestNextSweepStep(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  string
+		status models.TransactionStatus
+		want   string
+	}{
+		{name: "top-up unknown to the node", state: models.SweepTopUpPending, want: sweepStepRebroadcast},
+		{name: "top-up in the pending pool", state: models.SweepTopUpPending, status: models.TransactionStatus{Found: true}, want: sweepStepWait},
+		{name: "top-up mined", state: models.SweepTopUpPending, status: models.TransactionStatus{Found: true, Mined: true, Success: true}, want: sweepStepTransfer},
+		{name: "top-up reverted", state: models.SweepTopUpPending, status: models.TransactionStatus{Found: true, Mined: true}, want: sweepStepFail},
+		{name: "transfer unknown to the node", state: models.SweepTransferPending, want: sweepStepRebroadcast},
+		{name: "transfer in the pending pool", state: models.SweepTransferPending, status: models.TransactionStatus{Found: true}, want: sweepStepWait},
+		{name: "transfer mined", state: models.SweepTransferPending, status: models.TransactionStatus{Found: true, Mined: true, Success: true}, want: sweepStepComplete},
+		{name: "transfer reverted", state: models.SweepTransferPending, status: models.TransactionStatus{Found: true, Mined: true}, want: sweepStepFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextSweepStep(tt.state, tt.status); got != tt.want {
+				t.Errorf("nextSweepStep() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPendingSweepTransaction(t *testing.T) {
+	topUp := &models.SignedTransaction{Hash: "0xtopup"}
+	transfer := &models.SignedTransaction{Hash: "0xtransfer"}
+
+	tests := []struct {
+		name      string
+		sweep     models.Sweep
+		want      *models.SignedTransaction
+		wantFinal bool
+	}{
+		{name: "top-up pending", sweep: models.Sweep{State: models.SweepTopUpPending, TopUp: topUp}, want: topUp},
+		{name: "transfer pending after a top-up", sweep: models.Sweep{State: models.SweepTransferPending, TopUp: topUp, Transfer: transfer}, want: transfer},
+		{name: "transfer pending without its transaction", sweep: models.Sweep{State: models.SweepTransferPending, TopUp: topUp}},
+		{name: "completed", sweep: models.Sweep{State: models.SweepCompleted, Transfer: transfer}, wantFinal: true},
+		{name: "failed", sweep: models.Sweep{State: models.SweepFailed, TopUp: topUp}, wantFinal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pendingSweepTransaction(tt.sweep); got != tt.want {
+				t.Errorf("pendingSweepTransaction() = %v, want %v", got, tt.want)
+			}
+			if got := tt.sweep.Final(); got != tt.wantFinal {
+				t.Errorf("Final() = %v, want %v", got, tt.wantFinal)
+			}
+		})
+	}
+}
+
+// fakeSweeps records the saved sweeps.
+type fakeSweeps struct {
+	saved []models.Sweep
+}
+
+func (f *fakeSweeps) SaveSweep(ctx context.Context, sweep models.Sweep) (err error) {
+	f.saved = append(f.saved, sweep)
+	return nil
+}
+
+func (f *fakeSweeps) GetSweep(ctx context.Context, network, address string) (sweep models.Sweep, err error) {
+	return
+}
+
+func TestResumeSweepWithoutPendingTransaction(t *testing.T) {
+	sweeps := &fakeSweeps{}
+	s := &Service{Sweeps: sweeps}
+	sweep := models.Sweep{ID: "sweep", Network: "ERC20", State: models.SweepTransferPending}
+
+	ctx := context.WithValue(context.Background(), "request_id", "test")
+	if err := s.resumeSweep(ctx, &sweep, "", config.SweeperNetwork{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sweeps.saved) != 1 || sweeps.saved[0].State != models.SweepFailed {
+		t.Fatalf("saved = %+v, want a single failed sweep", sweeps.saved)
+	}
+	steps := sweeps.saved[0].Steps
+	if len(steps) != 1 || steps[0].State != models.SweepFailed || steps[0].Error == "" {
+		t.Errorf("steps = %+v, want a failed step with an error", steps)
+	}
+}
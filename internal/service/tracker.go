@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+)
+
+func (s *Service) TrackTransaction(ctx context.Context, networkName string, req models.TrackTransactionReq) (resp models.TrackedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.TrackTransaction()"),
+		slog.String("hash", req.Hash),
+	)
+
+	network, err := s.detectTrackedNetwork(networkName, req.Hash)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("network", networkName))
+		return
+	}
+	if req.Replaces != "" {
+		_, err = s.detectTrackedNetwork(networkName, req.Replaces)
+		if err != nil {
+			logger.Warn(err.Error(), slog.String("replaces", req.Replaces))
+			return
+		}
+	}
+
+	// registration is idempotent
+	resp, err = s.TrackedTransactions.GetTrackedTransaction(ctx, network, req.Hash)
+	if err != nil || resp.Hash != "" {
+		return
+	}
+
+	now := time.Now().UTC()
+	resp = models.TrackedTransaction{
+		Network:    network,
+		Hash:       req.Hash,
+		Replaces:   req.Replaces,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	setTrackedTransactionState(&resp, models.TrackedTransactionPending, "registered", now)
+
+	if req.Replaces != "" {
+		var replaced models.TrackedTransaction
+		replaced, err = s.TrackedTransactions.GetTrackedTransaction(ctx, network, req.Replaces)
+		if err != nil {
+			return
+		}
+		if replaced.Hash != "" && !replaced.Final {
+			replaced.ReplacedBy = req.Hash
+			replaced.UpdatedAt = now
+			err = s.TrackedTransactions.SaveTrackedTransaction(ctx, replaced)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	err = s.TrackedTransactions.SaveTrackedTransaction(ctx, resp)
+	if err != nil {
+		return
+	}
+
+	logger.Info("transaction registered for tracking", slog.String("network", network))
+	return
+}
+
+func (s *Service) GetTrackedTransaction(ctx context.Context, networkName, hash string) (resp models.TrackedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.GetTrackedTransaction()"),
+		slog.String("hash", hash),
+	)
+
+	network, err := utils.DetectNetworkByName(networkName)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("network", networkName))
+		return
+	}
+
+	resp, err = s.TrackedTransactions.GetTrackedTransaction(ctx, network, hash)
+	if err != nil {
+		return
+	}
+	if resp.Hash == "" {
+		err = ErrTrackedTransactionNotFound
+		logger.Warn(err.Error())
+		return
+	}
+	return
+}
+
+// RunTransactionTracker polls the state of tracked transactions until ctx is done.
+func (s *Service) RunTransactionTracker(ctx context.Context) {
+	slog.Info("starting transaction tracker...")
+	ticker := time.NewTicker(time.Duration(s.Config.Service.Tracker.PollInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("transaction tracker stopped")
+			return
+		case <-ticker.C:
+			s.pollTrackedTransactions(backgroundContext(ctx))
+		}
+	}
+}
+
+func (s *Service) pollTrackedTransactions(ctx context.Context) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.pollTrackedTransactions()"),
+	)
+
+	trxs, err := s.TrackedTransactions.ListActiveTrackedTransactions(ctx)
+	if err != nil {
+		return
+	}
+
+	latestBlocks := make(map[string]uint64)
+	for _, trx := range trxs {
+		latestBlock, ok := latestBlocks[trx.Network]
+		if !ok {
+			latestBlock, err = s.getBlockNumber(ctx, trx.Network)
+			if err != nil {
+				continue
+			}
+			latestBlocks[trx.Network] = latestBlock
+		}
+
+		err = s.updateTrackedTransaction(ctx, trx, latestBlock)
+		if err != nil {
+			logger.Warn("failed to update tracked transaction", slog.String("hash", trx.Hash), slog.Any("error", err))
+		}
+	}
+}
+
+func (s *Service) updateTrackedTransaction(ctx context.Context, trx models.TrackedTransaction, latestBlock uint64) (err error) {
+	status, err := s.getTransactionStatus(ctx, trx.Network, trx.Hash)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	if status.From != "" {
+		// needed to detect a replacement when the transaction is removed by a reorganisation
+		trx.From = status.From
+		trx.Nonce = status.Nonce
+	}
+	switch {
+	case status.Mined:
+		trx.LastSeenAt = now
+		trx.BlockNumber = status.BlockNumber
+		trx.Confirmations = 0
+		if latestBlock >= status.BlockNumber {
+			trx.Confirmations = latestBlock - status.BlockNumber + 1
+		}
+		confirmed := trx.Confirmations >= s.requiredConfirmations(trx.Network)
+
+		switch {
+		case !status.Success:
+			setTrackedTransactionState(&trx, models.TrackedTransactionReverted, "execution failed", now)
+			trx.Final = confirmed
+		case confirmed:
+			if trx.State != models.TrackedTransactionConfirmed {
+				s.publishEvent(ctx, models.EventTransactionConfirmed, trx.Network, trx.Hash, models.TransactionConfirmedEvent{
+					Hash:          trx.Hash,
+					BlockNumber:   trx.BlockNumber,
+					Confirmations: trx.Confirmations,
+				})
+			}
+			setTrackedTransactionState(&trx, models.TrackedTransactionConfirmed, "", now)
+			trx.Final = true
+		default:
+			setTrackedTransactionState(&trx, models.TrackedTransactionMined, "", now)
+		}
+
+		if trx.Replaces != "" {
+			err = s.markTrackedTransactionReplaced(ctx, trx.Network, trx.Replaces, trx.Hash)
+			if err != nil {
+				return
+			}
+		}
+	case status.Found:
+		trx.LastSeenAt = now
+		trx.BlockNumber = 0
+		trx.Confirmations = 0
+		setTrackedTransactionState(&trx, models.TrackedTransactionPending, "", now)
+	default:
+		trx.BlockNumber = 0
+		trx.Confirmations = 0
+		if trx.State == models.TrackedTransactionMined || trx.State == models.TrackedTransactionReverted {
+			setTrackedTransactionState(&trx, models.TrackedTransactionPending, "removed from chain by reorganisation", now)
+		}
+		if now.Sub(trx.LastSeenAt) < time.Duration(s.Config.Service.Tracker.DropTimeout)*time.Second {
+			break
+		}
+
+		replaced, err := s.isNonceConsumed(ctx, trx)
+		if err != nil {
+			return err
+		}
+		if replaced {
+			setTrackedTransactionState(&trx, models.TrackedTransactionReplaced, "nonce used by another transaction", now)
+		} else {
+			setTrackedTransactionState(&trx, models.TrackedTransactionDropped, "not seen by the node", now)
+		}
+		trx.Final = true
+	}
+
+	trx.UpdatedAt = now
+	return s.TrackedTransactions.SaveTrackedTransaction(ctx, trx)
+}
+
+// markTrackedTransactionReplaced finalizes a transaction whose speed-up or cancel replacement got mined.
+func (s *Service) markTrackedTransactionReplaced(ctx context.Context, network, hash, replacedBy string) (err error) {
+	trx, err := s.TrackedTransactions.GetTrackedTransaction(ctx, network, hash)
+	if err != nil || trx.Hash == "" || trx.Final {
+		return
+	}
+
+	now := time.Now().UTC()
+	trx.ReplacedBy = replacedBy
+	trx.Final = true
+	trx.UpdatedAt = now
+	setTrackedTransactionState(&trx, models.TrackedTransactionReplaced, "replacement "+replacedBy+" mined", now)
+	return s.TrackedTransactions.SaveTrackedTransaction(ctx, trx)
+}
+
+// isNonceConsumed reports whether the sender's nonce moved past the transaction,
+// which means another transaction with the same nonce (speed-up or cancel) was mined.
+func (s *Service) isNonceConsumed(ctx context.Context, trx models.TrackedTransaction) (bool, error) {
+	if trx.Network != "ERC20" || trx.From == "" {
+		return false, nil
+	}
+
+	nonce, err := s.External.Ethereum.GetNonce(ctx, trx.From)
+	if err != nil {
+		return false, err
+	}
+	return nonce > trx.Nonce, nil
+}
+
+func (s *Service) detectTrackedNetwork(networkName, hash string) (network string, err error) {
+	network, err = utils.DetectNetworkByName(networkName)
+	if err != nil {
+		return
+	}
+
+	hashNetwork, err := utils.DetectNetworkByHash(hash)
+	if err != nil {
+		return
+	}
+	if hashNetwork != network {
+		err = ErrHashNetworkMismatch
+		return
+	}
+	return
+}
+
+// setTrackedTransactionState changes the state and appends it to the history when it differs from the current one.
+func setTrackedTransactionState(trx *models.TrackedTransaction, state, reason string, now time.Time) {
+	if trx.State == state {
+		return
+	}
+
+	trx.State = state
+	trx.History = append(trx.History, models.TrackedTransactionEvent{
+		State:       state,
+		BlockNumber: trx.BlockNumber,
+		Reason:      reason,
+		Time:        now,
+	})
+}
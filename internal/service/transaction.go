@@ -2,14 +2,15 @@ package service
 
 import (
 	"context"
-	"errors"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/OwodDEV/crypto-service/internal/models"
 	"github.com/OwodDEV/crypto-service/pkg/utils"
 )
 
-func (s *Service) GetTransaction(ctx context.Context, hash string) (resp models.GetTransactionResp, err error) {
+func (s *Service) GetTransaction(ctx context.Context, hash string, cacheControl models.CacheControl) (resp models.GetTransactionResp, err error) {
 	logger := slog.With(
 		slog.String("request_id", ctx.Value("request_id").(string)),
 		slog.String("func", "service.GetTransaction()"),
@@ -21,31 +22,93 @@ func (s *Service) GetTransaction(ctx context.Context, hash string) (resp models.
 		return
 	}
 
-	var trxData models.Transaction
-	switch network {
-	case "ERC20":
-		trxData, err = s.External.Ethereum.GetTransaction(ctx, hash, "USDT")
+	// check for cached transaction
+	var trxData models.CachedTransaction
+	if !cacheControl.NoCache {
+		trxData, err = s.Transactions.GetTransaction(ctx, network, hash)
 		if err != nil {
-			return resp, err
+			logger.Warn("bypassing transaction cache", slog.Any("error", err))
 		}
-	case "TRC20":
-		trxData, err = s.External.Tron.GetTransaction(ctx, hash, "USDT")
-		if err != nil {
-			return resp, err
-		}
-	default:
-		err = errors.New("unsupported network")
-		logger.Warn(err.Error(), slog.String("network", network))
+	}
+
+	source := models.DataSourceCache
+	if trxData.Hash == "" || !isCacheAllowed(cacheControl, trxData.CachedAt) {
+		// get realtime transaction, concurrent lookups of the hash share one upstream call
+		source = models.DataSourceLive
+		notBefore := time.Now().UTC()
+		trxData, err = coalesce(ctx, s, "transaction:"+network+":"+strings.ToLower(hash),
+			func(ctx context.Context) (models.CachedTransaction, bool, error) {
+				trxData, err := s.Transactions.GetTransaction(ctx, network, hash)
+				return trxData, trxData.Hash != "" && !trxData.CachedAt.Before(notBefore), err
+			},
+			func(ctx context.Context) (trxData models.CachedTransaction, err error) {
+				// the transaction is read at this block or a later one
+				latestBlock, err := s.getBlockNumber(ctx, network)
+				if err != nil {
+					return
+				}
+
+				switch network {
+				case "ERC20":
+					trxData.Transaction, err = s.External.Ethereum.GetTransaction(ctx, hash, "USDT")
+					if err != nil {
+						return
+					}
+				case "TRC20":
+					trxData.Transaction, err = s.External.Tron.GetTransaction(ctx, hash, "USDT")
+					if err != nil {
+						return
+					}
+				default:
+					err = ErrUnsupportedNetwork
+					logger.Warn(err.Error(), slog.String("network", network))
+					if err != nil {
+						return
+					}
+				}
+				trxData.CachedAt = time.Now().UTC()
+				trxData.BlockNumber = latestBlock
+
+				_ = s.Transactions.SaveTransaction(ctx, network, hash, trxData, s.isTransactionFinal(ctx, network, hash, latestBlock))
+				return
+			},
+		)
 		if err != nil {
 			return
 		}
 	}
 
+	senderMatches, senderIncomplete, err := s.screenAddress(ctx, network, trxData.From, models.ScreeningPartySender)
+	if err != nil {
+		return
+	}
+	recipientMatches, recipientIncomplete, err := s.screenAddress(ctx, network, trxData.To, models.ScreeningPartyRecipient)
+	if err != nil {
+		return
+	}
+
 	resp = models.GetTransactionResp{
-		From:   trxData.From,
-		To:     trxData.To,
-		Amount: trxData.Amount,
+		From:                trxData.From,
+		To:                  trxData.To,
+		Amount:              trxData.Amount,
+		Source:              source,
+		BlockNumber:         trxData.BlockNumber,
+		Screening:           append(senderMatches, recipientMatches...),
+		ScreeningIncomplete: senderIncomplete || recipientIncomplete,
+	}
+	if !trxData.CachedAt.IsZero() {
+		resp.CachedAt = &trxData.CachedAt
 	}
 
 	return
 }
+
+// isTransactionFinal reports whether the transaction has the required number of confirmations
+// at latestBlock, so its result can not change anymore. Lookup failures count as not final.
+func (s *Service) isTransactionFinal(ctx context.Context, network, hash string, latestBlock uint64) bool {
+	status, err := s.getTransactionStatus(ctx, network, hash)
+	if err != nil || !status.Mined || latestBlock < status.BlockNumber {
+		return false
+	}
+	return latestBlock-status.BlockNumber+1 >= s.requiredConfirmations(network)
+}
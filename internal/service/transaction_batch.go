@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// LookupTransactions looks up transactions of any network concurrently. Failures are reported per hash.
+func (s *Service) LookupTransactions(ctx context.Context, hashes []string, cacheControl models.CacheControl) (resp models.LookupTransactionsResp, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.LookupTransactions()"),
+		slog.Int("hashes", len(hashes)),
+	)
+
+	if len(hashes) > s.Config.Service.Batch.MaxTransactions {
+		err = ErrTooManyBatchItems
+		logger.Warn(err.Error())
+		return
+	}
+
+	resp.Items = make([]models.TransactionLookupResult, len(hashes))
+	var group errgroup.Group
+	group.SetLimit(max(s.Config.Service.Batch.Parallelism, 1))
+	for i, hash := range hashes {
+		result := &resp.Items[i]
+		result.Hash = hash
+		result.Network, _ = utils.DetectNetworkByHash(hash)
+		group.Go(func() error {
+			trx, err := s.GetTransaction(ctx, hash, cacheControl)
+			if err != nil {
+				result.Err = err
+				return nil
+			}
+			result.Transaction = &trx
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	logger.Info("transactions looked up")
+	return
+}
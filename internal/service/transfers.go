@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+// watchedTokens are the tokens whose transfers are followed by the transfer watcher.
+var watchedTokens = []string{"USDT"}
+
+// transferWatcherLockTTL bounds a single pass of the watcher over a block range.
+const transferWatcherLockTTL = 5 * time.Minute
+
+// TransferHandler is called for every confirmed token transfer seen by the transfer watcher. The blocks
+// are processed again when a handler fails, so handlers must tolerate transfers they have already seen.
+type TransferHandler func(ctx context.Context, transfer models.TransferEvent) (err error)
+
+// OnTransfer registers a handler of observed transfers. It must be called before RunTransferWatcher.
+func (s *Service) OnTransfer(handler TransferHandler) {
+	s.transferHandlers = append(s.transferHandlers, handler)
+}
+
+// RunTransferWatcher follows confirmed blocks of every network and dispatches
+// their token transfers to the registered handlers until ctx is done.
+func (s *Service) RunTransferWatcher(ctx context.Context) {
+	slog.Info("starting transfer watcher...")
+	ticker := time.NewTicker(time.Duration(s.Config.Service.TransferWatcher.PollInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("transfer watcher stopped")
+			return
+		case <-ticker.C:
+			s.watchTransfers(backgroundContext(ctx), "ERC20")
+			s.watchTransfers(backgroundContext(ctx), "TRC20")
+		}
+	}
+}
+
+func (s *Service) watchTransfers(ctx context.Context, network string) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.watchTransfers()"),
+		slog.String("network", network),
+	)
+
+	// the cursor is shared, so only one replica processes the next block range
+	lockName := "transfer_watcher:" + network
+	token, locked, err := s.Locks.AcquireLock(ctx, lockName, transferWatcherLockTTL)
+	if err != nil || !locked {
+		return
+	}
+	defer s.Locks.ReleaseLock(ctx, lockName, token)
+
+	latestBlock, err := s.getBlockNumber(ctx, network)
+	if err != nil {
+		return
+	}
+
+	// only blocks with the required number of confirmations are processed
+	confirmations := max(s.requiredConfirmations(network), 1)
+	if latestBlock+1 < confirmations {
+		return
+	}
+	safeBlock := latestBlock + 1 - confirmations
+
+	cursor, err := s.TransferWatcher.GetTransferWatcherCursor(ctx, network)
+	if err != nil {
+		return
+	}
+	if cursor == 0 {
+		// first run, start from the current head instead of the chain history
+		_ = s.TransferWatcher.SaveTransferWatcherCursor(ctx, network, safeBlock)
+		return
+	}
+	if cursor >= safeBlock {
+		return
+	}
+
+	fromBlock := cursor + 1
+	toBlock := min(safeBlock, cursor+s.Config.Service.TransferWatcher.MaxBlocks)
+
+	var transfers []models.TransferEvent
+	for _, token := range watchedTokens {
+		tokenTransfers, err := s.getTokenTransfers(ctx, network, token, fromBlock, toBlock)
+		if err != nil {
+			return
+		}
+		transfers = append(transfers, tokenTransfers...)
+	}
+
+	// the cursor only moves once every transfer is handled, failed blocks are retried on the next tick
+	for _, transfer := range transfers {
+		for _, handler := range s.transferHandlers {
+			err = handler(ctx, transfer)
+			if err != nil {
+				logger.Warn("failed to handle transfer, retrying the blocks later",
+					slog.String("hash", transfer.Hash),
+					slog.Uint64("from_block", fromBlock),
+					slog.Any("error", err),
+				)
+				return
+			}
+		}
+	}
+
+	err = s.TransferWatcher.SaveTransferWatcherCursor(ctx, network, toBlock)
+	if err != nil {
+		logger.Error("failed to save transfer watcher cursor", slog.Any("error", err))
+		return
+	}
+
+	logger.Debug("processed blocks",
+		slog.Uint64("from_block", fromBlock),
+		slog.Uint64("to_block", toBlock),
+		slog.Int("transfers", len(transfers)),
+	)
+}
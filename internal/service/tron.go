@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+)
+
+func (s *Service) GetTronAccountResources(ctx context.Context, address string) (resp models.GetTronAccountResourcesResp, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.GetTronAccountResources()"),
+	)
+
+	network, err := utils.DetectNetworkByAddr(address)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("address", address))
+		return
+	}
+	if network != "TRC20" {
+		err = ErrNotTronAddress
+		logger.Warn(err.Error(), slog.String("address", address))
+		return
+	}
+
+	return s.External.Tron.GetAccountResources(ctx, address)
+}
@@ -2,57 +2,132 @@ package service
 
 import (
 	"context"
-	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/OwodDEV/crypto-service/internal/models"
 	"github.com/OwodDEV/crypto-service/pkg/utils"
 )
 
-func (s *Service) GetWallet(ctx context.Context, address string) (resp models.GetWalletResp, err error) {
+func (s *Service) GetWallet(ctx context.Context, address string, cacheControl models.CacheControl) (resp models.GetWalletResp, err error) {
 	logger := slog.With(
 		slog.String("request_id", ctx.Value("request_id").(string)),
 		slog.String("func", "service.GetBalance()"),
 	)
 
+	network, err := utils.DetectNetworkByAddr(address)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("address", address))
+		return
+	}
+
 	// check for cached balance
-	balance, err := s.Cache.GetWalletBalance(ctx, address)
+	contract, err := s.tokenContract(network, "USDT")
 	if err != nil {
 		return
 	}
-	if balance != "" {
-		resp.Balance = balance
+	normalized := utils.NormalizeAddress(network, address)
+	var cached models.CachedBalance
+	if !cacheControl.NoCache {
+		// a failing cache is bypassed, the balance is then read from the node
+		cached, err = s.Cache.GetWalletBalance(ctx, network, contract, normalized)
+		if err != nil {
+			logger.Warn("bypassing wallet balance cache", slog.Any("error", err))
+		}
+	}
+
+	balance, source, err := s.walletBalance(ctx, network, contract, address, "USDT", cached, cacheControl)
+	if err != nil {
 		return
 	}
+	resp.Balance = balance.Balance
+	resp.Source = source
+	resp.CachedAt = &balance.CachedAt
+	resp.BlockNumber = balance.BlockNumber
+	resp.Stale = balance.Stale
 
-	// get realtime balance
-	network, err := utils.DetectNetworkByAddr(address)
+	// frozen funds can not be moved, so the status is reported with the balance, it is left
+	// out when the issuer contract can not be read
+	frozen, err := s.isBlacklisted(ctx, network, address, "USDT")
+	if err != nil {
+		logger.Warn("freeze status is unavailable", slog.String("address", address), slog.Any("error", err))
+		err = nil
+	} else {
+		resp.Frozen = &frozen
+	}
+
+	resp.Screening, resp.ScreeningIncomplete, err = s.screenAddress(ctx, network, address, models.ScreeningPartyAddress)
 	if err != nil {
-		logger.Warn(err.Error(), slog.String("address", address))
 		return
 	}
+	return
+}
+
+// walletBalance serves the cached balance when it satisfies the balance cache settings and the
+// client's cache control, and reads it from the node otherwise.
+func (s *Service) walletBalance(ctx context.Context, network, contract, address, token string, cached models.CachedBalance, cacheControl models.CacheControl) (balance models.CachedBalance, source string, err error) {
+	cfg := s.Config.Service.BalanceCache
+	controlled := isCacheControlled(cacheControl)
+	switch {
+	case cached.Balance != "" && controlled && isCacheAllowed(cacheControl, cached.CachedAt):
+		return cached, models.DataSourceCache, nil
+	case cached.Balance != "" && !controlled && !cached.Stale:
+		return cached, models.DataSourceCache, nil
+	case cached.Balance != "" && !controlled && cfg.StaleWhileRevalidate:
+		// serve the stale balance right away and refresh it in the background
+		s.refreshWalletBalance(ctx, network, contract, address, token)
+		return cached, models.DataSourceCache, nil
+	}
 
-	switch network {
-	case "ERC20":
-		balance, err = s.External.Ethereum.GetBalance(ctx, address, "USDT")
-		if err != nil {
-			return
-		}
-	case "TRC20":
-		balance, err = s.External.Tron.GetBalance(ctx, address, "USDT")
-		if err != nil {
+	// get realtime balance, a client asking for a fresh one gets the error instead of a stale balance
+	balance, err = s.fetchWalletBalance(ctx, network, contract, address, token, time.Now().UTC())
+	if err != nil {
+		if cached.Balance == "" || !cfg.StaleIfError || controlled {
 			return
 		}
-	default:
-		err = errors.New("unsupported network")
-		logger.Warn(err.Error(), slog.String("network", network))
-		if err != nil {
+		slog.Warn("serving stale balance on upstream failure",
+			slog.String("request_id", ctx.Value("request_id").(string)),
+			slog.String("address", address),
+			slog.Any("error", err),
+		)
+		return cached, models.DataSourceCache, nil
+	}
+	return balance, models.DataSourceLive, nil
+}
+
+// fetchWalletBalance reads the balance from the node and caches it. Concurrent lookups
+// of the address share one upstream call. Balances another replica cached before notBefore are not used.
+func (s *Service) fetchWalletBalance(ctx context.Context, network, contract, address, token string, notBefore time.Time) (balance models.CachedBalance, err error) {
+	normalized := utils.NormalizeAddress(network, address)
+	return coalesce(ctx, s, "wallet_balance:"+network+":"+contract+":"+normalized,
+		func(ctx context.Context) (models.CachedBalance, bool, error) {
+			cached, err := s.Cache.GetWalletBalance(ctx, network, contract, normalized)
+			return cached, cached.Balance != "" && !cached.Stale && !cached.CachedAt.Before(notBefore), err
+		},
+		func(ctx context.Context) (balance models.CachedBalance, err error) {
+			balance.Balance, balance.BlockNumber, err = s.getTokenBalanceAtHead(ctx, network, address, token)
+			if err != nil {
+				return
+			}
+			balance.CachedAt = time.Now().UTC()
+
+			_, _ = s.Cache.SaveWalletBalance(ctx, network, contract, normalized, balance)
+			s.publishBalanceUpdate(ctx, network, address, token, balance.Balance)
 			return
-		}
+		},
+	)
+}
+
+// refreshWalletBalance starts a background refresh of a stale balance, unless one is already running.
+func (s *Service) refreshWalletBalance(ctx context.Context, network, contract, address, token string) {
+	key := network + ":" + contract + ":" + utils.NormalizeAddress(network, address)
+	if _, running := s.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
 	}
 
-	// save and response
-	_ = s.Cache.SaveWalletBalance(ctx, address, balance)
-	resp.Balance = balance
-	return
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer s.refreshing.Delete(key)
+		_, _ = s.fetchWalletBalance(ctx, network, contract, address, token, time.Time{})
+	}()
 }
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// GetWalletBalances looks up the balances of several addresses. The cached balances are read in
+// one batch and the rest is fetched from the nodes concurrently. Failures are reported per item.
+func (s *Service) GetWalletBalances(ctx context.Context, items []models.WalletBalanceItem, cacheControl models.CacheControl) (resp models.GetWalletBalancesResp, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.GetWalletBalances()"),
+		slog.Int("items", len(items)),
+	)
+
+	if len(items) > s.Config.Service.Batch.MaxBalances {
+		err = ErrTooManyBatchItems
+		logger.Warn(err.Error())
+		return
+	}
+
+	resp.Items = make([]models.WalletBalanceResult, len(items))
+	keys := make([]models.WalletBalanceKey, 0, len(items))
+	resolved := make([]int, 0, len(items))
+	for i, item := range items {
+		result := &resp.Items[i]
+		result.Address = item.Address
+		result.Token = item.Token
+		if result.Token == "" {
+			result.Token = "USDT"
+		}
+
+		key, err := s.walletBalanceKey(item.Network, item.Address, result.Token)
+		if err != nil {
+			result.Err = err
+			continue
+		}
+		result.Network = key.Network
+		keys = append(keys, key)
+		resolved = append(resolved, i)
+	}
+
+	cached := make([]models.CachedBalance, len(keys))
+	if !cacheControl.NoCache && len(keys) > 0 {
+		// a failing cache is bypassed, the balances are then read from the nodes
+		loaded, err := s.Cache.GetWalletBalances(ctx, keys)
+		if err != nil {
+			logger.Warn("bypassing wallet balance cache", slog.Any("error", err))
+		} else {
+			cached = loaded
+		}
+	}
+
+	var group errgroup.Group
+	group.SetLimit(max(s.Config.Service.Batch.Parallelism, 1))
+	for j, i := range resolved {
+		result := &resp.Items[i]
+		group.Go(func() error {
+			balance, source, err := s.walletBalance(ctx, result.Network, keys[j].Contract, result.Address, result.Token, cached[j], cacheControl)
+			if err != nil {
+				result.Err = err
+				return nil
+			}
+			result.Balance = balance.Balance
+			result.Source = source
+			result.CachedAt = &balance.CachedAt
+			result.BlockNumber = balance.BlockNumber
+			result.Stale = balance.Stale
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	logger.Info("wallet balances looked up")
+	return
+}
+
+// walletBalanceKey resolves the network and the token contract of a balance lookup.
+func (s *Service) walletBalanceKey(networkName, address, token string) (key models.WalletBalanceKey, err error) {
+	if networkName != "" {
+		key.Network, err = detectAddressNetwork(networkName, address)
+	} else {
+		key.Network, err = utils.DetectNetworkByAddr(address)
+	}
+	if err != nil {
+		return
+	}
+
+	key.Contract, err = s.tokenContract(key.Network, token)
+	if err != nil {
+		return
+	}
+	key.Address = utils.NormalizeAddress(key.Network, address)
+	return
+}
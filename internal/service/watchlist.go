@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+)
+
+func (s *Service) CreateWatchedAddress(ctx context.Context, req models.CreateWatchedAddressReq) (resp models.WatchedAddress, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.CreateWatchedAddress()"),
+		slog.String("address", req.Address),
+	)
+
+	network, err := detectAddressNetwork(req.Network, req.Address)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("network", req.Network))
+		return
+	}
+
+	now := time.Now().UTC()
+	resp = models.WatchedAddress{
+		Network:   network,
+		Address:   req.Address,
+		Tokens:    req.Tokens,
+		Label:     req.Label,
+		OwnerRef:  req.OwnerRef,
+		Balances:  map[string]string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	created, err := s.Watchlist.CreateWatchedAddress(ctx, resp, utils.NormalizeAddress(network, req.Address))
+	if err != nil {
+		return
+	}
+	if !created {
+		err = ErrAddressAlreadyWatched
+		logger.Warn(err.Error())
+		return
+	}
+
+	logger.Info("address added to the watchlist", slog.String("network", network))
+	return
+}
+
+func (s *Service) UpdateWatchedAddress(ctx context.Context, networkName, address string, req models.UpdateWatchedAddressReq) (resp models.WatchedAddress, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.UpdateWatchedAddress()"),
+		slog.String("address", address),
+	)
+
+	watched, err := s.GetWatchedAddress(ctx, networkName, address)
+	if err != nil {
+		return
+	}
+
+	// the entry is changed in a transaction, so balances saved by a concurrent poll are kept
+	now := time.Now().UTC()
+	resp, updated, err := s.Watchlist.UpdateWatchedAddress(ctx, watched.Network, utils.NormalizeAddress(watched.Network, watched.Address), func(watched *models.WatchedAddress) bool {
+		watched.Tokens = req.Tokens
+		watched.Label = req.Label
+		watched.OwnerRef = req.OwnerRef
+		watched.UpdatedAt = now
+		for token := range watched.Balances {
+			if !containsToken(watched.Tokens, token) {
+				delete(watched.Balances, token)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return
+	}
+	if !updated {
+		err = ErrWatchedAddressNotFound
+		logger.Warn(err.Error())
+		return
+	}
+	return
+}
+
+func (s *Service) GetWatchedAddress(ctx context.Context, networkName, address string) (resp models.WatchedAddress, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.GetWatchedAddress()"),
+		slog.String("address", address),
+	)
+
+	network, err := detectAddressNetwork(networkName, address)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("network", networkName))
+		return
+	}
+
+	resp, err = s.Watchlist.GetWatchedAddress(ctx, network, utils.NormalizeAddress(network, address))
+	if err != nil {
+		return
+	}
+	if resp.Address == "" {
+		err = ErrWatchedAddressNotFound
+		logger.Warn(err.Error())
+		return
+	}
+	return
+}
+
+func (s *Service) ListWatchedAddresses(ctx context.Context) (resp []models.WatchedAddress, err error) {
+	return s.Watchlist.ListWatchedAddresses(ctx)
+}
+
+func (s *Service) DeleteWatchedAddress(ctx context.Context, networkName, address string) (err error) {
+	watched, err := s.GetWatchedAddress(ctx, networkName, address)
+	if err != nil {
+		return
+	}
+	return s.Watchlist.DeleteWatchedAddress(ctx, watched.Network, utils.NormalizeAddress(watched.Network, watched.Address))
+}
+
+func (s *Service) ListBalanceChanges(ctx context.Context, networkName, address string) (resp []models.BalanceChange, err error) {
+	watched, err := s.GetWatchedAddress(ctx, networkName, address)
+	if err != nil {
+		return
+	}
+	return s.Watchlist.ListBalanceChanges(ctx, watched.Network, utils.NormalizeAddress(watched.Network, watched.Address))
+}
+
+// RunWatchlistPoller refreshes the balances of watched addresses and records
+// their changes until ctx is done.
+func (s *Service) RunWatchlistPoller(ctx context.Context) {
+	slog.Info("starting watchlist poller...")
+	ticker := time.NewTicker(time.Duration(s.Config.Service.Watchlist.PollInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("watchlist poller stopped")
+			return
+		case <-ticker.C:
+			s.pollWatchlist(backgroundContext(ctx))
+		}
+	}
+}
+
+func (s *Service) pollWatchlist(ctx context.Context) {
+	list, err := s.Watchlist.ListWatchedAddresses(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, watched := range list {
+		if ctx.Err() != nil {
+			return
+		}
+		s.refreshWatchedAddress(ctx, watched)
+	}
+}
+
+func (s *Service) refreshWatchedAddress(ctx context.Context, watched models.WatchedAddress) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.refreshWatchedAddress()"),
+		slog.String("address", watched.Address),
+	)
+
+	normalized := utils.NormalizeAddress(watched.Network, watched.Address)
+	now := time.Now().UTC()
+	balances := make(map[string]string, len(watched.Tokens))
+
+	for _, token := range watched.Tokens {
+		balance, err := s.getTokenBalance(ctx, watched.Network, watched.Address, token)
+		if err != nil {
+			return
+		}
+
+		previous, known := watched.Balances[token]
+		if known && previous != balance {
+			err = s.Watchlist.AddBalanceChange(ctx, watched.Network, normalized, models.BalanceChange{
+				Token:    token,
+				Previous: previous,
+				Current:  balance,
+				Time:     now,
+			})
+			if err != nil {
+				return
+			}
+			// a change is observed by a single poll, identified by its time
+			key := fmt.Sprintf("%s:%s:%d", normalized, token, now.UnixNano())
+			s.publishEvent(ctx, models.EventBalanceChanged, watched.Network, key, models.BalanceChangedEvent{
+				Address:  watched.Address,
+				Token:    token,
+				Previous: previous,
+				Current:  balance,
+			})
+			logger.Info("watched address balance changed",
+				slog.String("token", token),
+				slog.String("previous", previous),
+				slog.String("current", balance),
+			)
+		}
+		balances[token] = balance
+		s.publishBalanceUpdate(ctx, watched.Network, watched.Address, token, balance)
+
+		contract, err := s.tokenContract(watched.Network, token)
+		if err == nil {
+			_, _ = s.Cache.SaveWalletBalance(ctx, watched.Network, contract, normalized, models.CachedBalance{Balance: balance, CachedAt: now})
+		}
+	}
+
+	// only the polled fields are written, the entry may have been updated or deleted meanwhile
+	_, updated, err := s.Watchlist.UpdateWatchedAddress(ctx, watched.Network, normalized, func(watched *models.WatchedAddress) bool {
+		for _, token := range watched.Tokens {
+			if balance, ok := balances[token]; ok {
+				watched.Balances[token] = balance
+			}
+		}
+		watched.CheckedAt = &now
+		return true
+	})
+	if err != nil {
+		logger.Error("failed to save watched address balances", slog.Any("error", err))
+		return
+	}
+	if !updated {
+		logger.Info("watched address deleted during the poll")
+	}
+}
+
+// detectAddressNetwork resolves the network name and checks that the address belongs to it.
+func detectAddressNetwork(networkName, address string) (network string, err error) {
+	network, err = utils.DetectNetworkByName(networkName)
+	if err != nil {
+		return
+	}
+
+	addressNetwork, err := utils.DetectNetworkByAddr(address)
+	if err != nil {
+		return
+	}
+	if addressNetwork != network {
+		err = ErrAddressNetworkMismatch
+		return
+	}
+	return
+}
+
+func containsToken(tokens []string, token string) bool {
+	for _, t := range tokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
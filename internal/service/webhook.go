@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// webhookDispatchBatch limits the deliveries attempted per dispatcher tick.
+const webhookDispatchBatch = 100
+
+func (s *Service) CreateWebhookSubscription(ctx context.Context, req models.CreateWebhookSubscriptionReq) (resp models.WebhookSubscription, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.CreateWebhookSubscription()"),
+	)
+
+	addresses, err := normalizeAddresses(req.Addresses)
+	if err != nil {
+		logger.Warn(err.Error())
+		return
+	}
+
+	// the service must not be used to reach internal hosts
+	err = s.External.Webhook.ValidateURL(ctx, req.URL)
+	if err != nil {
+		logger.Warn(err.Error(), slog.String("url", req.URL))
+		return
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		logger.Error("failed to generate webhook secret", slog.Any("error", err))
+		return
+	}
+
+	resp = models.WebhookSubscription{
+		ID:        uuid.New().String(),
+		URL:       req.URL,
+		Secret:    hex.EncodeToString(secret),
+		Addresses: req.Addresses,
+		CreatedAt: time.Now().UTC(),
+	}
+	err = s.Webhooks.SaveWebhookSubscription(ctx, resp, addresses)
+	if err != nil {
+		return
+	}
+
+	logger.Info("webhook subscription created", slog.String("subscription_id", resp.ID))
+	return
+}
+
+func (s *Service) GetWebhookSubscription(ctx context.Context, id string) (resp models.WebhookSubscription, err error) {
+	resp, err = s.getWebhookSubscription(ctx, id)
+	resp.Secret = ""
+	return
+}
+
+func (s *Service) DeleteWebhookSubscription(ctx context.Context, id string) (err error) {
+	subscription, err := s.getWebhookSubscription(ctx, id)
+	if err != nil {
+		return
+	}
+
+	addresses, err := normalizeAddresses(subscription.Addresses)
+	if err != nil {
+		return
+	}
+	return s.Webhooks.DeleteWebhookSubscription(ctx, subscription, addresses)
+}
+
+func (s *Service) ListWebhookDeliveries(ctx context.Context, id string) (resp []models.WebhookDelivery, err error) {
+	_, err = s.getWebhookSubscription(ctx, id)
+	if err != nil {
+		return
+	}
+	return s.Webhooks.ListWebhookDeliveries(ctx, id)
+}
+
+func (s *Service) getWebhookSubscription(ctx context.Context, id string) (subscription models.WebhookSubscription, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.getWebhookSubscription()"),
+		slog.String("subscription_id", id),
+	)
+
+	subscription, err = s.Webhooks.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return
+	}
+	if subscription.ID == "" {
+		err = ErrWebhookNotFound
+		logger.Warn(err.Error())
+		return
+	}
+	return
+}
+
+// notifyWebhooks is a TransferHandler queueing a delivery for every subscription
+// watching the sender (outgoing event) or the recipient (incoming event).
+func (s *Service) notifyWebhooks(ctx context.Context, transfer models.TransferEvent) (err error) {
+	err = s.queueWebhookEvents(ctx, transfer, transfer.To, models.WebhookEventTransferIncoming)
+	if err != nil {
+		return
+	}
+	return s.queueWebhookEvents(ctx, transfer, transfer.From, models.WebhookEventTransferOutgoing)
+}
+
+func (s *Service) queueWebhookEvents(ctx context.Context, transfer models.TransferEvent, address, eventType string) (err error) {
+	ids, err := s.Webhooks.ListWebhookSubscriptionIDsByAddress(ctx, utils.NormalizeAddress(transfer.Network, address))
+	if err != nil || len(ids) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	event := models.WebhookEvent{
+		// deterministic, so subscribers can deduplicate retried deliveries
+		ID:        fmt.Sprintf("%s:%s:%d:%s", transfer.Network, transfer.Hash, transfer.LogIndex, eventType),
+		Type:      eventType,
+		Address:   address,
+		CreatedAt: now,
+		Data:      transfer,
+	}
+	for _, id := range ids {
+		delivery := models.WebhookDelivery{
+			// one delivery per event and subscription, however often the blocks are processed
+			ID:             event.ID + ":" + id,
+			SubscriptionID: id,
+			Event:          event,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		_, err = s.Webhooks.CreateWebhookDelivery(ctx, delivery)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// RunWebhookDispatcher sends queued webhook deliveries, retrying failures with
+// exponential backoff, until ctx is done.
+func (s *Service) RunWebhookDispatcher(ctx context.Context) {
+	slog.Info("starting webhook dispatcher...")
+	ticker := time.NewTicker(time.Duration(s.Config.Service.Webhooks.DispatchInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+			s.dispatchWebhooks(backgroundContext(ctx))
+		}
+	}
+}
+
+func (s *Service) dispatchWebhooks(ctx context.Context) {
+	// deliveries stay queued until their outcome is saved, so they are retried after a crash.
+	// Each one is leased right before it is sent, so the lease covers a single attempt
+	leaseTimeout := time.Duration(s.Config.Service.Webhooks.LeaseTimeout) * time.Second
+	for range webhookDispatchBatch {
+		if ctx.Err() != nil {
+			return
+		}
+
+		now := time.Now()
+		id, err := s.Webhooks.LeaseDueWebhookDelivery(ctx, now, now.Add(leaseTimeout))
+		if err != nil || id == "" {
+			return
+		}
+
+		delivery, err := s.Webhooks.GetWebhookDelivery(ctx, id)
+		if err != nil {
+			continue
+		}
+		if delivery.ID == "" || delivery.Status != models.WebhookDeliveryPending {
+			_ = s.Webhooks.DequeueWebhookDelivery(ctx, id)
+			continue
+		}
+		s.deliverWebhook(ctx, delivery)
+	}
+}
+
+func (s *Service) deliverWebhook(ctx context.Context, delivery models.WebhookDelivery) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "service.deliverWebhook()"),
+		slog.String("delivery_id", delivery.ID),
+		slog.String("subscription_id", delivery.SubscriptionID),
+	)
+
+	subscription, err := s.Webhooks.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		// the delivery is attempted again when its lease is over
+		return
+	}
+
+	delivery.Attempts++
+	delivery.UpdatedAt = time.Now().UTC()
+	switch {
+	case subscription.ID == "":
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = "subscription deleted"
+	default:
+		body, err := json.Marshal(delivery.Event)
+		if err != nil {
+			logger.Error("failed to marshal webhook event", slog.Any("error", err))
+			return
+		}
+
+		delivery.LastStatusCode, err = s.External.Webhook.Send(ctx, subscription.URL, subscription.Secret, delivery.Event.ID, body)
+		delivery.LastError = ""
+		if err != nil {
+			delivery.LastError = err.Error()
+		} else if delivery.LastStatusCode < 200 || delivery.LastStatusCode > 299 {
+			delivery.LastError = fmt.Sprintf("unexpected status code %d", delivery.LastStatusCode)
+		}
+
+		switch {
+		case delivery.LastError == "":
+			delivery.Status = models.WebhookDeliveryDelivered
+		case delivery.Attempts >= s.Config.Service.Webhooks.MaxAttempts:
+			delivery.Status = models.WebhookDeliveryFailed
+			logger.Warn("webhook delivery failed", slog.Int("attempts", delivery.Attempts), slog.String("error", delivery.LastError))
+		default:
+			delivery.NextAttemptAt = delivery.UpdatedAt.Add(s.webhookBackoff(delivery.Attempts))
+		}
+	}
+
+	_ = s.Webhooks.SaveWebhookDelivery(ctx, delivery)
+}
+
+// webhookBackoff returns the delay before the next attempt: backoff_base * 2^(attempts-1), capped at backoff_max.
+func (s *Service) webhookBackoff(attempts int) time.Duration {
+	base := time.Duration(s.Config.Service.Webhooks.BackoffBase) * time.Second
+	limit := time.Duration(s.Config.Service.Webhooks.BackoffMax) * time.Second
+
+	delay := base
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
+
+func normalizeAddresses(addresses []string) (normalized []string, err error) {
+	for _, address := range addresses {
+		network, err := utils.DetectNetworkByAddr(address)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, address)
+		}
+		normalized = append(normalized, utils.NormalizeAddress(network, address))
+	}
+	return
+}
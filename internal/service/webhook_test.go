@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     int64
+		max      int64
+		attempts int
+		want     time.Duration
+	}{
+		{name: "first attempt waits the base", base: 5, max: 3600, attempts: 1, want: 5 * time.Second},
+		{name: "zero attempts waits the base", base: 5, max: 3600, attempts: 0, want: 5 * time.Second},
+		{name: "second attempt doubles", base: 5, max: 3600, attempts: 2, want: 10 * time.Second},
+		{name: "fifth attempt", base: 5, max: 3600, attempts: 5, want: 80 * time.Second},
+		{name: "capped at the maximum", base: 5, max: 3600, attempts: 11, want: time.Hour},
+		{name: "many attempts do not overflow", base: 5, max: 3600, attempts: 1000, want: time.Hour},
+		{name: "base above the maximum", base: 120, max: 60, attempts: 1, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Service.Webhooks.BackoffBase = tt.base
+			cfg.Service.Webhooks.BackoffMax = tt.max
+			s := &Service{Config: cfg}
+
+			if got := s.webhookBackoff(tt.attempts); got != tt.want {
+				t.Errorf("webhookBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+)
+
+const activeBalanceAlertsKey = "balance_alerts:active"
+
+func balanceAlertID(network, address, token string) string {
+	return network + ":" + address + ":" + token
+}
+
+// ActivateBalanceAlert marks the low balance alert as active and reports whether it was not active before.
+func (s *Storage) ActivateBalanceAlert(ctx context.Context, network, address, token string) (activated bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ActivateBalanceAlert()"),
+		slog.String("network", network),
+		slog.String("address", address),
+		slog.String("token", token),
+	)
+
+	added, err := s.client.SAdd(ctx, activeBalanceAlertsKey, balanceAlertID(network, address, token)).Result()
+	if err != nil {
+		logger.Error("failed to activate balance alert in cache", slog.Any("error", err))
+		return
+	}
+	return added == 1, nil
+}
+
+// ResolveBalanceAlert marks the low balance alert as resolved and reports whether it was active before.
+func (s *Storage) ResolveBalanceAlert(ctx context.Context, network, address, token string) (resolved bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ResolveBalanceAlert()"),
+		slog.String("network", network),
+		slog.String("address", address),
+		slog.String("token", token),
+	)
+
+	removed, err := s.client.SRem(ctx, activeBalanceAlertsKey, balanceAlertID(network, address, token)).Result()
+	if err != nil {
+		logger.Error("failed to resolve balance alert in cache", slog.Any("error", err))
+		return
+	}
+	return removed == 1, nil
+}
@@ -1,49 +1,175 @@
 package cache
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
 
 	"github.com/redis/go-redis/v9"
 )
 
-func walletBalanceKey(address string) string {
-	return "wallet_balance:" + address
+// walletBalanceSchema is the version of the wallet balance keys and values. Increasing it
+// makes the service ignore the entries of the previous format until they expire.
+const walletBalanceSchema = "v3"
+
+// walletBalanceInvalidationTTL is how long an invalidated balance keeps its tombstone, which rejects
+// balances read before the invalidating block by lookups that were in flight during the invalidation.
+const walletBalanceInvalidationTTL = time.Minute
+
+// saveWalletBalanceScript saves the balance unless the entry is a tombstone of a later block.
+var saveWalletBalanceScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current then
+	local invalidated = string.match(current, "^invalidated:(%d+)$")
+	if invalidated and tonumber(invalidated) > tonumber(ARGV[3]) then
+		return 0
+	end
+end
+if tonumber(ARGV[2]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+else
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+func walletBalanceTombstone(blockNumber uint64) string {
+	return "invalidated:" + strconv.FormatUint(blockNumber, 10)
+}
+
+func isWalletBalanceTombstone(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("invalidated:"))
 }
 
-func (s *Storage) SaveWalletBalance(ctx context.Context, address, balance string) (err error) {
+// walletBalanceKey scopes the balance by network and token contract, since one address may exist
+// on several networks and hold several tokens. The contract and the address are expected in their normalized form.
+func walletBalanceKey(network, contract, address string) string {
+	return "wallet_balance:" + walletBalanceSchema + ":" + network + ":" + contract + ":" + address
+}
+
+// SaveWalletBalance caches the balance for the fresh TTL of the network plus its stale TTL,
+// during which the entry is still returned, marked as stale. The balance is not saved when it
+// was read at a block before the one which invalidated it.
+func (s *Storage) SaveWalletBalance(ctx context.Context, network, contract, address string, cached models.CachedBalance) (saved bool, err error) {
 	logger := slog.With(
 		slog.String("request_id", ctx.Value("request_id").(string)),
 		slog.String("func", "storage.cache.SaveWalletBalance()"),
+		slog.String("network", network),
 		slog.String("address", address),
 	)
 
-	err = s.client.Set(ctx, walletBalanceKey(address), balance, s.walletBalanceTTL).Err()
+	data, err := json.Marshal(cached)
+	if err != nil {
+		logger.Error("failed to marshal wallet balance", slog.Any("error", err))
+		return
+	}
+
+	ttl := s.walletBalanceTTL[network] + s.walletBalanceStaleTTL[network]
+	saved, err = saveWalletBalanceScript.Run(ctx, s.client, []string{walletBalanceKey(network, contract, address)},
+		data, ttl.Milliseconds(), cached.BlockNumber).Bool()
 	if err != nil {
 		logger.Error("failed to save wallet balance to cache", slog.Any("error", err))
 		return
 	}
+	if !saved {
+		logger.Info("wallet balance read before its invalidation was not saved", slog.Uint64("block_number", cached.BlockNumber))
+		return
+	}
 
 	logger.Info("successfully saved wallet balance to cache")
 	return
 }
 
-func (s *Storage) GetWalletBalance(ctx context.Context, address string) (balance string, err error) {
+// GetWalletBalances returns the cached balances of the keys in one round trip, in the order of the keys.
+// A pipeline of GETs is used instead of MGET, since in cluster mode the keys span several slots.
+func (s *Storage) GetWalletBalances(ctx context.Context, keys []models.WalletBalanceKey) (cached []models.CachedBalance, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetWalletBalances()"),
+		slog.Int("keys", len(keys)),
+	)
+
+	cmds := make([]*redis.StringCmd, len(keys))
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, walletBalanceKey(key.Network, key.Contract, key.Address))
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		logger.Error("failed to get wallet balances from cache", slog.Any("error", err))
+		return
+	}
+	err = nil
+
+	cached = make([]models.CachedBalance, len(keys))
+	for i, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil || isWalletBalanceTombstone(data) {
+			continue
+		}
+
+		err = json.Unmarshal(data, &cached[i])
+		if err != nil {
+			logger.Error("failed to unmarshal wallet balance", slog.Any("error", err))
+			cached[i] = models.CachedBalance{}
+			continue
+		}
+		cached[i].Stale = time.Since(cached[i].CachedAt) > s.walletBalanceTTL[keys[i].Network]
+	}
+
+	logger.Info("successfully loaded wallet balances from cache")
+	return
+}
+
+// InvalidateWalletBalance replaces the cached balance with a tombstone of the block which changed it,
+// so the next lookup reads it from the node and balances read before that block are not cached.
+func (s *Storage) InvalidateWalletBalance(ctx context.Context, network, contract, address string, blockNumber uint64) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.InvalidateWalletBalance()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	err = s.client.Set(ctx, walletBalanceKey(network, contract, address), walletBalanceTombstone(blockNumber), walletBalanceInvalidationTTL).Err()
+	if err != nil {
+		logger.Error("failed to delete wallet balance from cache", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// GetWalletBalance returns the cached balance, with an empty balance when it is not cached.
+func (s *Storage) GetWalletBalance(ctx context.Context, network, contract, address string) (cached models.CachedBalance, err error) {
 	logger := slog.With(
 		slog.String("request_id", ctx.Value("request_id").(string)),
 		slog.String("func", "storage.cache.GetWalletBalance()"),
+		slog.String("network", network),
 		slog.String("address", address),
 	)
 
-	balance, err = s.client.Get(ctx, walletBalanceKey(address)).Result()
-	if err == redis.Nil {
-		return "", nil
+	data, err := s.client.Get(ctx, walletBalanceKey(network, contract, address)).Bytes()
+	if err == redis.Nil || (err == nil && isWalletBalanceTombstone(data)) {
+		return cached, nil
 	}
 	if err != nil {
 		logger.Error("failed to get wallet balance from cache", slog.Any("error", err))
 		return
 	}
 
-	logger.Info("successfully loaded wallet balance from cache")
+	err = json.Unmarshal(data, &cached)
+	if err != nil {
+		logger.Error("failed to unmarshal wallet balance", slog.Any("error", err))
+		return
+	}
+	cached.Stale = time.Since(cached.CachedAt) > s.walletBalanceTTL[network]
+
+	logger.Info("successfully loaded wallet balance from cache", slog.Bool("stale", cached.Stale))
 	return
 }
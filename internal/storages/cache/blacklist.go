@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func blacklistStatusKey(network, address, token string) string {
+	return "blacklist_status:" + network + ":" + token + ":" + address
+}
+
+func (s *Storage) SaveBlacklistStatus(ctx context.Context, network, address, token string, blacklisted bool) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveBlacklistStatus()"),
+		slog.String("network", network),
+		slog.String("address", address),
+		slog.String("token", token),
+	)
+
+	err = s.client.Set(ctx, blacklistStatusKey(network, address, token), blacklisted, s.blacklistTTL).Err()
+	if err != nil {
+		logger.Error("failed to save blacklist status to cache", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// GetBlacklistStatus returns the cached status, found is false when it is not cached.
+func (s *Storage) GetBlacklistStatus(ctx context.Context, network, address, token string) (blacklisted, found bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetBlacklistStatus()"),
+		slog.String("network", network),
+		slog.String("address", address),
+		slog.String("token", token),
+	)
+
+	blacklisted, err = s.client.Get(ctx, blacklistStatusKey(network, address, token)).Bool()
+	if err == redis.Nil {
+		return false, false, nil
+	}
+	if err != nil {
+		logger.Error("failed to get blacklist status from cache", slog.Any("error", err))
+		return
+	}
+	return blacklisted, true, nil
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func blockPollerCursorKey(network string) string {
+	return "block_poller:cursor:" + network
+}
+
+func (s *Storage) SaveBlockPollerCursor(ctx context.Context, network string, block uint64) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveBlockPollerCursor()"),
+		slog.String("network", network),
+	)
+
+	err = s.client.Set(ctx, blockPollerCursorKey(network), block, 0).Err()
+	if err != nil {
+		logger.Error("failed to save block poller cursor to cache", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) GetBlockPollerCursor(ctx context.Context, network string) (block uint64, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetBlockPollerCursor()"),
+		slog.String("network", network),
+	)
+
+	block, err = s.client.Get(ctx, blockPollerCursorKey(network)).Uint64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		logger.Error("failed to get block poller cursor from cache", slog.Any("error", err))
+		return
+	}
+	return
+}
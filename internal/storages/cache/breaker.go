@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrCacheUnavailable = models.NewError(models.ErrUnavailable, "cache_unavailable", "cache is unavailable")
+
+// probeKey marks the health check commands, which are let through an open breaker.
+type probeKey struct{}
+
+// circuitBreaker stops sending commands to Redis after consecutive connection failures, so
+// callers fail fast and bypass the cache. After the cooldown a single command probes Redis again.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	lastError string
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.probing || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if !isConnectionError(err) {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		b.lastError = ""
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	if b.failures >= b.threshold || !b.openedAt.IsZero() {
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *circuitBreaker) open(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = b.threshold
+	b.openedAt = time.Now()
+	b.lastError = err.Error()
+}
+
+func (b *circuitBreaker) status() (status models.ComponentStatus) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status.Status = models.ComponentStatusUp
+	if !b.openedAt.IsZero() {
+		status.Status = models.ComponentStatusDown
+		openedAt := b.openedAt.UTC()
+		status.Since = &openedAt
+	}
+	status.LastError = b.lastError
+	return
+}
+
+// isConnectionError tells connection problems apart from replies of a working server.
+func isConnectionError(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return false
+	}
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		return false
+	}
+	return true
+}
+
+type breakerHook struct {
+	breaker *circuitBreaker
+}
+
+func (h breakerHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h breakerHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if ctx.Value(probeKey{}) == nil && !h.breaker.allow() {
+			cmd.SetErr(ErrCacheUnavailable)
+			return ErrCacheUnavailable
+		}
+
+		err := next(ctx, cmd)
+		h.breaker.record(err)
+		return err
+	}
+}
+
+func (h breakerHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if ctx.Value(probeKey{}) == nil && !h.breaker.allow() {
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrCacheUnavailable)
+			}
+			return ErrCacheUnavailable
+		}
+
+		err := next(ctx, cmds)
+		h.breaker.record(err)
+		return err
+	}
+}
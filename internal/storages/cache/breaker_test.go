@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// replyError is an error reply of a working Redis server.
+type replyError string
+
+func (e replyError) Error() string { return string(e) }
+func (replyError) RedisError()     {}
+
+func TestCircuitBreaker(t *testing.T) {
+	errConn := errors.New("dial tcp: connection refused")
+	errReply := replyError("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+	// a step either records the result of a command or checks whether the next one is allowed
+	type step struct {
+		record error
+		allow  *bool
+	}
+	recorded := func(err error) step { return step{record: err} }
+	allowed := func(want bool) step { return step{allow: &want} }
+
+	tests := []struct {
+		name       string
+		cooldown   time.Duration
+		steps      []step
+		wantStatus string
+	}{
+		{
+			name:       "stays closed below the threshold",
+			cooldown:   time.Hour,
+			steps:      []step{recorded(errConn), recorded(errConn), allowed(true)},
+			wantStatus: models.ComponentStatusUp,
+		},
+		{
+			name:       "opens at the threshold",
+			cooldown:   time.Hour,
+			steps:      []step{recorded(errConn), recorded(errConn), recorded(errConn), allowed(false), allowed(false)},
+			wantStatus: models.ComponentStatusDown,
+		},
+		{
+			name:       "a success resets the failure count",
+			cooldown:   time.Hour,
+			steps:      []step{recorded(errConn), recorded(errConn), recorded(nil), recorded(errConn), allowed(true)},
+			wantStatus: models.ComponentStatusUp,
+		},
+		{
+			name:       "server replies are not failures",
+			cooldown:   time.Hour,
+			steps:      []step{recorded(errReply), recorded(redis.Nil), recorded(errReply), recorded(errConn), allowed(true)},
+			wantStatus: models.ComponentStatusUp,
+		},
+		{
+			name:       "canceled commands are ignored",
+			cooldown:   time.Hour,
+			steps:      []step{recorded(errConn), recorded(errConn), recorded(context.Canceled), recorded(errConn), allowed(false)},
+			wantStatus: models.ComponentStatusDown,
+		},
+		{
+			name:       "lets a single probe through after the cooldown",
+			cooldown:   0,
+			steps:      []step{recorded(errConn), recorded(errConn), recorded(errConn), allowed(true), allowed(false)},
+			wantStatus: models.ComponentStatusDown,
+		},
+		{
+			name:       "a successful probe closes the breaker",
+			cooldown:   0,
+			steps:      []step{recorded(errConn), recorded(errConn), recorded(errConn), allowed(true), recorded(nil), allowed(true), allowed(true)},
+			wantStatus: models.ComponentStatusUp,
+		},
+		{
+			name:     "a failed probe opens the breaker again",
+			cooldown: 0,
+			steps: []step{
+				recorded(errConn), recorded(errConn), recorded(errConn),
+				allowed(true), recorded(errConn), allowed(true), allowed(false),
+			},
+			wantStatus: models.ComponentStatusDown,
+		},
+		{
+			name:       "a canceled probe lets the next one through",
+			cooldown:   0,
+			steps:      []step{recorded(errConn), recorded(errConn), recorded(errConn), allowed(true), recorded(context.Canceled), allowed(true)},
+			wantStatus: models.ComponentStatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := &circuitBreaker{threshold: 3, cooldown: tt.cooldown}
+			for i, step := range tt.steps {
+				if step.allow == nil {
+					breaker.record(step.record)
+					continue
+				}
+				if got := breaker.allow(); got != *step.allow {
+					t.Fatalf("step %d: allow() = %v, want %v", i, got, *step.allow)
+				}
+			}
+			if got := breaker.status().Status; got != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerOpen(t *testing.T) {
+	breaker := &circuitBreaker{threshold: 3, cooldown: time.Hour}
+	breaker.open(errors.New("ping failed"))
+
+	if breaker.allow() {
+		t.Error("allow() = true after open, want false")
+	}
+	status := breaker.status()
+	if status.Status != models.ComponentStatusDown || status.Since == nil || status.LastError != "ping failed" {
+		t.Errorf("status = %+v, want down since now with the ping error", status)
+	}
+}
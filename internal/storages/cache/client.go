@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	modeStandalone = "standalone"
+	modeSentinel   = "sentinel"
+	modeCluster    = "cluster"
+)
+
+// newClient builds a single node, Sentinel or Cluster client. All of them implement
+// redis.UniversalClient, so the storage code does not depend on the deployment.
+func newClient(cfg *config.Config) (client redis.UniversalClient, err error) {
+	cacheCfg := cfg.Storages.Cache
+
+	addrs := cacheCfg.Addresses
+	if len(addrs) == 0 {
+		addrs = []string{cacheCfg.Host + ":" + cacheCfg.Port}
+	}
+
+	opts := &redis.UniversalOptions{
+		Addrs:            addrs,
+		Username:         cacheCfg.Username,
+		Password:         cacheCfg.Password,
+		DB:               cacheCfg.DBIndex,
+		SentinelUsername: cacheCfg.Sentinel.Username,
+		SentinelPassword: cacheCfg.Sentinel.Password,
+	}
+	if cacheCfg.TLS.Enabled {
+		opts.TLSConfig, err = newTLSConfig(cfg)
+		if err != nil {
+			return
+		}
+	}
+
+	switch cacheCfg.Mode {
+	case modeStandalone, "":
+		client = redis.NewClient(opts.Simple())
+	case modeSentinel:
+		if cacheCfg.Sentinel.MasterName == "" {
+			err = errors.New("sentinel master name is not set")
+			return
+		}
+		opts.MasterName = cacheCfg.Sentinel.MasterName
+		client = redis.NewFailoverClient(opts.Failover())
+	case modeCluster:
+		// a single seed node is enough to discover the cluster. Keys changed in one transaction
+		// share a hash tag, otherwise the client would split the transaction per slot
+		client = redis.NewClusterClient(opts.Cluster())
+	default:
+		err = errors.New("unknown cache mode " + cacheCfg.Mode)
+	}
+	return
+}
+
+func newTLSConfig(cfg *config.Config) (tlsConfig *tls.Config, err error) {
+	tlsCfg := cfg.Storages.Cache.TLS
+
+	tlsConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         tlsCfg.ServerName,
+		InsecureSkipVerify: tlsCfg.InsecureSkipVerify,
+	}
+
+	if tlsCfg.CAFile != "" {
+		var ca []byte
+		ca, err = os.ReadFile(tlsCfg.CAFile)
+		if err != nil {
+			return
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			err = errors.New("no certificates found in " + tlsCfg.CAFile)
+			return
+		}
+	}
+
+	// client certificate for mutual TLS
+	if tlsCfg.CertFile != "" {
+		var cert tls.Certificate
+		cert, err = tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// eventStreamKey returns the stream of an event type, so consumer groups can read only the types they need.
+func (s *Storage) eventStreamKey(eventType string) string {
+	return s.Config.Storages.Cache.EventStreamPrefix + ":" + eventType
+}
+
+// PublishEvent appends the event to the Redis stream of its type, trimming the stream to the configured length.
+func (s *Storage) PublishEvent(ctx context.Context, event models.Event) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.PublishEvent()"),
+		slog.String("type", event.Type),
+		slog.String("event_id", event.ID),
+	)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("failed to marshal event", slog.Any("error", err))
+		return
+	}
+
+	err = s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.eventStreamKey(event.Type),
+		MaxLen: s.Config.Storages.Cache.EventStreamMaxLen,
+		Approx: true,
+		Values: map[string]any{
+			"schema_version": strconv.Itoa(event.SchemaVersion),
+			"type":           event.Type,
+			"event":          data,
+		},
+	}).Err()
+	if err != nil {
+		logger.Error("failed to publish event to stream", slog.Any("error", err))
+		return
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+const cacheInvalidationsChannel = "cache_invalidations"
+
+// PublishCacheInvalidation tells the other replicas to drop their in-process copy of the key.
+func (s *Storage) PublishCacheInvalidation(ctx context.Context, instanceID, key string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.PublishCacheInvalidation()"),
+		slog.String("key", key),
+	)
+
+	data, err := json.Marshal(models.CacheInvalidation{InstanceID: instanceID, Key: key})
+	if err != nil {
+		logger.Error("failed to marshal cache invalidation", slog.Any("error", err))
+		return
+	}
+
+	err = s.client.Publish(ctx, cacheInvalidationsChannel, data).Err()
+	if err != nil {
+		logger.Error("failed to publish cache invalidation", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// SubscribeCacheInvalidations returns the invalidations published by all replicas. The channel is closed when ctx is done.
+func (s *Storage) SubscribeCacheInvalidations(ctx context.Context) <-chan models.CacheInvalidation {
+	pubsub := s.client.Subscribe(ctx, cacheInvalidationsChannel)
+	invalidations := make(chan models.CacheInvalidation)
+
+	go func() {
+		defer close(invalidations)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var invalidation models.CacheInvalidation
+				err := json.Unmarshal([]byte(msg.Payload), &invalidation)
+				if err != nil {
+					slog.Error("failed to unmarshal cache invalidation", slog.Any("error", err))
+					continue
+				}
+
+				select {
+				case invalidations <- invalidation:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return invalidations
+}
@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// an invoice and its settlement schedule are saved in one transaction, so in cluster mode
+// both keys share the {invoices} hash tag
+const settlingInvoicesKey = "{invoices}:settling"
+
+func invoiceKey(id string) string {
+	return "{invoices}:invoice:" + id
+}
+
+func invoiceAddressKey(network, address string) string {
+	return "invoice_address:" + network + ":" + address
+}
+
+// ReserveInvoiceAddress assigns the receiving address to the invoice unless another invoice holds it.
+func (s *Storage) ReserveInvoiceAddress(ctx context.Context, network, address, invoiceID string, ttl time.Duration) (ok bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ReserveInvoiceAddress()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	ok, err = s.client.SetNX(ctx, invoiceAddressKey(network, address), invoiceID, ttl).Result()
+	if err != nil {
+		logger.Error("failed to reserve invoice address", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) GetInvoiceIDByAddress(ctx context.Context, network, address string) (invoiceID string, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetInvoiceIDByAddress()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	invoiceID, err = s.client.Get(ctx, invoiceAddressKey(network, address)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		logger.Error("failed to get invoice by address", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// CreateInvoice saves a new invoice and schedules its settlement at settleAt.
+func (s *Storage) CreateInvoice(ctx context.Context, invoice models.Invoice, settleAt time.Time) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.CreateInvoice()"),
+		slog.String("invoice_id", invoice.ID),
+	)
+
+	data, err := json.Marshal(invoice)
+	if err != nil {
+		logger.Error("failed to marshal invoice", slog.Any("error", err))
+		return
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, invoiceKey(invoice.ID), data, time.Until(settleAt)+s.invoiceTTL)
+		pipe.ZAdd(ctx, settlingInvoicesKey, redis.Z{Score: float64(settleAt.Unix()), Member: invoice.ID})
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to save invoice to cache", slog.Any("error", err))
+		return
+	}
+
+	logger.Info("successfully saved invoice to cache")
+	return
+}
+
+// UpdateInvoice applies update to the current invoice in a transaction, retried when the invoice changes
+// concurrently, so update may run several times. Nothing is written when the invoice does not exist or
+// update reports no change.
+func (s *Storage) UpdateInvoice(ctx context.Context, id string, update func(invoice *models.Invoice) (changed bool)) (invoice models.Invoice, updated bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.UpdateInvoice()"),
+		slog.String("invoice_id", id),
+	)
+
+	key := invoiceKey(id)
+	apply := func(tx *redis.Tx) error {
+		invoice, updated = models.Invoice{}, false
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(data, &invoice)
+		if err != nil {
+			return err
+		}
+		if !update(&invoice) {
+			return nil
+		}
+
+		data, err = json.Marshal(invoice)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, redis.KeepTTL)
+			return nil
+		})
+		updated = err == nil
+		return err
+	}
+
+	for attempt := 0; attempt < maxTxAttempts; attempt++ {
+		err = s.client.Watch(ctx, apply, key)
+		if err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		logger.Error("failed to update invoice", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) GetInvoice(ctx context.Context, id string) (invoice models.Invoice, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetInvoice()"),
+		slog.String("invoice_id", id),
+	)
+
+	data, err := s.client.Get(ctx, invoiceKey(id)).Bytes()
+	if err == redis.Nil {
+		return invoice, nil
+	}
+	if err != nil {
+		logger.Error("failed to get invoice from cache", slog.Any("error", err))
+		return
+	}
+
+	err = json.Unmarshal(data, &invoice)
+	if err != nil {
+		logger.Error("failed to unmarshal invoice", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// PopSettledInvoices returns the IDs of invoices whose settlement time has passed.
+// Each ID is returned once, even when several replicas call it concurrently.
+func (s *Storage) PopSettledInvoices(ctx context.Context, now time.Time) (ids []string, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.PopSettledInvoices()"),
+	)
+
+	candidates, err := s.client.ZRangeByScore(ctx, settlingInvoicesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.Unix(), 10),
+	}).Result()
+	if err != nil {
+		logger.Error("failed to list settled invoices", slog.Any("error", err))
+		return
+	}
+
+	for _, id := range candidates {
+		removed, err := s.client.ZRem(ctx, settlingInvoicesKey, id).Result()
+		if err != nil {
+			logger.Error("failed to remove settled invoice", slog.Any("error", err))
+			return nil, err
+		}
+		if removed > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// releaseLockScript deletes the lock only while it is still held by the same owner,
+// so an expired and re-acquired lock is not released by the previous owner.
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func lockKey(name string) string {
+	return "lock:" + name
+}
+
+// AcquireLock takes a short lived distributed lock. The returned token is needed to release it.
+func (s *Storage) AcquireLock(ctx context.Context, name string, ttl time.Duration) (token string, ok bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.AcquireLock()"),
+		slog.String("lock", name),
+	)
+
+	token = uuid.New().String()
+	ok, err = s.client.SetNX(ctx, lockKey(name), token, ttl).Result()
+	if err != nil {
+		logger.Error("failed to acquire lock", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) ReleaseLock(ctx context.Context, name, token string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ReleaseLock()"),
+		slog.String("lock", name),
+	)
+
+	err = releaseLockScript.Run(ctx, s.client, []string{lockKey(name)}, token).Err()
+	if err != nil {
+		logger.Error("failed to release lock", slog.Any("error", err))
+		return
+	}
+	return
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const screeningInternalListKey = "screening:internal"
+
+func screeningEntryField(network, address string) string {
+	return network + ":" + address
+}
+
+// SaveScreeningEntry saves an entry of the internal list under its normalized address.
+func (s *Storage) SaveScreeningEntry(ctx context.Context, entry models.ScreeningEntry, address string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveScreeningEntry()"),
+		slog.String("network", entry.Network),
+		slog.String("address", entry.Address),
+	)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		logger.Error("failed to marshal screening entry", slog.Any("error", err))
+		return
+	}
+
+	err = s.client.HSet(ctx, screeningInternalListKey, screeningEntryField(entry.Network, address), data).Err()
+	if err != nil {
+		logger.Error("failed to save screening entry to cache", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) GetScreeningEntry(ctx context.Context, network, address string) (entry models.ScreeningEntry, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetScreeningEntry()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	data, err := s.client.HGet(ctx, screeningInternalListKey, screeningEntryField(network, address)).Bytes()
+	if err == redis.Nil {
+		return entry, nil
+	}
+	if err != nil {
+		logger.Error("failed to get screening entry from cache", slog.Any("error", err))
+		return
+	}
+
+	err = json.Unmarshal(data, &entry)
+	if err != nil {
+		logger.Error("failed to unmarshal screening entry", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) ListScreeningEntries(ctx context.Context) (entries []models.ScreeningEntry, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ListScreeningEntries()"),
+	)
+
+	values, err := s.client.HVals(ctx, screeningInternalListKey).Result()
+	if err != nil {
+		logger.Error("failed to list screening entries from cache", slog.Any("error", err))
+		return
+	}
+
+	entries = make([]models.ScreeningEntry, 0, len(values))
+	for _, value := range values {
+		var entry models.ScreeningEntry
+		err = json.Unmarshal([]byte(value), &entry)
+		if err != nil {
+			logger.Error("failed to unmarshal screening entry", slog.Any("error", err))
+			return
+		}
+		entries = append(entries, entry)
+	}
+	return
+}
+
+func (s *Storage) DeleteScreeningEntry(ctx context.Context, network, address string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.DeleteScreeningEntry()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	err = s.client.HDel(ctx, screeningInternalListKey, screeningEntryField(network, address)).Err()
+	if err != nil {
+		logger.Error("failed to delete screening entry from cache", slog.Any("error", err))
+		return
+	}
+	return
+}
@@ -6,39 +6,104 @@ import (
 	"time"
 
 	"github.com/OwodDEV/crypto-service/internal/config"
+	"github.com/OwodDEV/crypto-service/internal/models"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// maxTxAttempts bounds the retries of optimistic transactions whose watched keys changed concurrently.
+const maxTxAttempts = 5
+
 type Storage struct {
-	Config           *config.Config
-	client           *redis.Client
-	walletBalanceTTL time.Duration
+	Config                *config.Config
+	client                redis.UniversalClient
+	breaker               *circuitBreaker
+	walletBalanceTTL      map[string]time.Duration
+	walletBalanceStaleTTL map[string]time.Duration
+	trackedTransactionTTL time.Duration
+	sweepTTL              time.Duration
+	invoiceTTL            time.Duration
+	webhookDeliveryTTL    time.Duration
+	blacklistTTL          time.Duration
+	finalTransactionTTL   time.Duration
+	pendingTransactionTTL time.Duration
 }
 
 func NewStorage(cfg *config.Config) (storage *Storage, err error) {
 	storage = &Storage{
 		Config: cfg,
+		breaker: &circuitBreaker{
+			threshold: max(cfg.Storages.Cache.Breaker.FailureThreshold, 1),
+			cooldown:  time.Duration(cfg.Storages.Cache.Breaker.Cooldown) * time.Second,
+		},
+	}
+	storage.walletBalanceTTL = map[string]time.Duration{
+		"ERC20": time.Duration(cfg.Storages.Cache.WalletBalanceTTL.Ethereum) * time.Second,
+		"TRC20": time.Duration(cfg.Storages.Cache.WalletBalanceTTL.Tron) * time.Second,
 	}
-	storage.walletBalanceTTL = time.Duration(cfg.Storages.Cache.WalletBalanceTTL) * time.Second
+	storage.walletBalanceStaleTTL = map[string]time.Duration{
+		"ERC20": time.Duration(cfg.Storages.Cache.WalletBalanceStaleTTL.Ethereum) * time.Second,
+		"TRC20": time.Duration(cfg.Storages.Cache.WalletBalanceStaleTTL.Tron) * time.Second,
+	}
+	storage.trackedTransactionTTL = time.Duration(cfg.Storages.Cache.TrackedTransactionTTL) * time.Second
+	storage.sweepTTL = time.Duration(cfg.Storages.Cache.SweepTTL) * time.Second
+	storage.invoiceTTL = time.Duration(cfg.Storages.Cache.InvoiceTTL) * time.Second
+	storage.webhookDeliveryTTL = time.Duration(cfg.Storages.Cache.WebhookDeliveryTTL) * time.Second
+	storage.blacklistTTL = time.Duration(cfg.Storages.Cache.BlacklistTTL) * time.Second
+	storage.finalTransactionTTL = time.Duration(cfg.Storages.Cache.FinalTransactionTTL) * time.Second
+	storage.pendingTransactionTTL = time.Duration(cfg.Storages.Cache.PendingTransactionTTL) * time.Second
 	return
 }
 
 func (s *Storage) Connect() (err error) {
 	slog.Info("initializing Cache storage connection...")
-	s.client = redis.NewClient(&redis.Options{
-		Addr:     s.Config.Storages.Cache.Host + ":" + s.Config.Storages.Cache.Port,
-		Password: s.Config.Storages.Cache.Password,
-		DB:       s.Config.Storages.Cache.DBIndex,
-	})
-
-	ctx := context.Background()
-	_, err = s.client.Ping(ctx).Result()
+	s.client, err = newClient(s.Config)
 	if err != nil {
-		slog.Error("unable to ping Cache storage", slog.Any("error", err))
+		slog.Error("unable to configure Cache storage client", slog.Any("error", err))
 		return
 	}
-	return
+	s.client.AddHook(breakerHook{breaker: s.breaker})
+
+	// the service keeps working without the cache, the health check reconnects later
+	ctx := context.WithValue(context.Background(), probeKey{}, true)
+	err = s.client.Ping(ctx).Err()
+	if err != nil {
+		slog.Warn("unable to ping Cache storage, running without cache", slog.Any("error", err))
+		s.breaker.open(err)
+	}
+	return nil
+}
+
+// RunHealthCheck pings Redis while it is unavailable and closes the circuit breaker
+// once it answers again, until ctx is done.
+func (s *Storage) RunHealthCheck(ctx context.Context) {
+	slog.Info("starting Cache storage health check...")
+	ticker := time.NewTicker(time.Duration(s.Config.Storages.Cache.HealthCheckInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Cache storage health check stopped")
+			return
+		case <-ticker.C:
+			if s.breaker.status().Status == models.ComponentStatusUp {
+				continue
+			}
+
+			err := s.client.Ping(context.WithValue(ctx, probeKey{}, true)).Err()
+			if err != nil {
+				slog.Warn("Cache storage is still unavailable", slog.Any("error", err))
+				continue
+			}
+			slog.Info("Cache storage connection restored")
+		}
+	}
+}
+
+// Status reports whether Redis is reachable.
+func (s *Storage) Status() models.ComponentStatus {
+	return s.breaker.status()
 }
 
 func (s *Storage) Shutdown() {
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+const streamEventsChannel = "stream_events"
+
+func (s *Storage) PublishStreamEvent(ctx context.Context, event models.StreamEvent) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.PublishStreamEvent()"),
+		slog.String("type", event.Type),
+	)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("failed to marshal stream event", slog.Any("error", err))
+		return
+	}
+
+	err = s.client.Publish(ctx, streamEventsChannel, data).Err()
+	if err != nil {
+		logger.Error("failed to publish stream event", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// SubscribeStreamEvents returns the events published by all replicas. The channel is closed when ctx is done.
+func (s *Storage) SubscribeStreamEvents(ctx context.Context) <-chan models.StreamEvent {
+	pubsub := s.client.Subscribe(ctx, streamEventsChannel)
+	events := make(chan models.StreamEvent)
+
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event models.StreamEvent
+				err := json.Unmarshal([]byte(msg.Payload), &event)
+				if err != nil {
+					slog.Error("failed to unmarshal stream event", slog.Any("error", err))
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func sweepKey(network, address string) string {
+	return "sweep:" + network + ":" + address
+}
+
+func (s *Storage) SaveSweep(ctx context.Context, sweep models.Sweep) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveSweep()"),
+		slog.String("network", sweep.Network),
+		slog.String("address", sweep.Address),
+	)
+
+	data, err := json.Marshal(sweep)
+	if err != nil {
+		logger.Error("failed to marshal sweep", slog.Any("error", err))
+		return
+	}
+
+	// a sweep in progress must survive until it is resumed
+	var ttl time.Duration
+	if sweep.Final() {
+		ttl = s.sweepTTL
+	}
+
+	err = s.client.Set(ctx, sweepKey(sweep.Network, sweep.Address), data, ttl).Err()
+	if err != nil {
+		logger.Error("failed to save sweep to cache", slog.Any("error", err))
+		return
+	}
+
+	logger.Debug("successfully saved sweep to cache", slog.String("state", sweep.State))
+	return
+}
+
+func (s *Storage) GetSweep(ctx context.Context, network, address string) (sweep models.Sweep, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetSweep()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	data, err := s.client.Get(ctx, sweepKey(network, address)).Bytes()
+	if err == redis.Nil {
+		return sweep, nil
+	}
+	if err != nil {
+		logger.Error("failed to get sweep from cache", slog.Any("error", err))
+		return
+	}
+
+	err = json.Unmarshal(data, &sweep)
+	if err != nil {
+		logger.Error("failed to unmarshal sweep", slog.Any("error", err))
+		return
+	}
+	return
+}
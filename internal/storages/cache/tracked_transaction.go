@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// the record and the index of active transactions are changed in one transaction, so in cluster
+// mode every key of tracked transactions shares the {tracked_transactions} hash tag
+const activeTrackedTransactionsKey = "{tracked_transactions}:active"
+
+func trackedTransactionID(network, hash string) string {
+	return network + ":" + strings.ToLower(hash)
+}
+
+func trackedTransactionKey(id string) string {
+	return "{tracked_transactions}:tracked_transaction:" + id
+}
+
+func (s *Storage) SaveTrackedTransaction(ctx context.Context, trx models.TrackedTransaction) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveTrackedTransaction()"),
+		slog.String("network", trx.Network),
+		slog.String("hash", trx.Hash),
+	)
+
+	data, err := json.Marshal(trx)
+	if err != nil {
+		logger.Error("failed to marshal tracked transaction", slog.Any("error", err))
+		return
+	}
+
+	// active transactions are kept until they are final, final ones expire after the retention period
+	id := trackedTransactionID(trx.Network, trx.Hash)
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if trx.Final {
+			pipe.Set(ctx, trackedTransactionKey(id), data, s.trackedTransactionTTL)
+			pipe.SRem(ctx, activeTrackedTransactionsKey, id)
+		} else {
+			pipe.Set(ctx, trackedTransactionKey(id), data, 0)
+			pipe.SAdd(ctx, activeTrackedTransactionsKey, id)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to save tracked transaction to cache", slog.Any("error", err))
+		return
+	}
+
+	logger.Debug("successfully saved tracked transaction to cache")
+	return
+}
+
+func (s *Storage) GetTrackedTransaction(ctx context.Context, network, hash string) (trx models.TrackedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetTrackedTransaction()"),
+		slog.String("network", network),
+		slog.String("hash", hash),
+	)
+
+	data, err := s.client.Get(ctx, trackedTransactionKey(trackedTransactionID(network, hash))).Bytes()
+	if err == redis.Nil {
+		return trx, nil
+	}
+	if err != nil {
+		logger.Error("failed to get tracked transaction from cache", slog.Any("error", err))
+		return
+	}
+
+	err = json.Unmarshal(data, &trx)
+	if err != nil {
+		logger.Error("failed to unmarshal tracked transaction", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) ListActiveTrackedTransactions(ctx context.Context) (trxs []models.TrackedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ListActiveTrackedTransactions()"),
+	)
+
+	ids, err := s.client.SMembers(ctx, activeTrackedTransactionsKey).Result()
+	if err != nil {
+		logger.Error("failed to list active tracked transactions", slog.Any("error", err))
+		return
+	}
+
+	for _, id := range ids {
+		data, err := s.client.Get(ctx, trackedTransactionKey(id)).Bytes()
+		if err == redis.Nil {
+			// the record is gone, drop the dangling index entry
+			s.client.SRem(ctx, activeTrackedTransactionsKey, id)
+			continue
+		}
+		if err != nil {
+			logger.Error("failed to get tracked transaction from cache", slog.String("id", id), slog.Any("error", err))
+			return nil, err
+		}
+
+		// a corrupted record must not stop tracking of the others
+		var trx models.TrackedTransaction
+		err = json.Unmarshal(data, &trx)
+		if err != nil {
+			logger.Error("failed to unmarshal tracked transaction, skipping", slog.String("id", id), slog.Any("error", err))
+			continue
+		}
+		trxs = append(trxs, trx)
+	}
+	return
+}
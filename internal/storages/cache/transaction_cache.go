@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// transactionKey ignores the hash case, hex hashes are case-insensitive.
+func transactionKey(network, hash string) string {
+	return "transaction:" + network + ":" + strings.ToLower(hash)
+}
+
+// SaveTransaction caches a transaction lookup result. Final results never change, so they are kept
+// for the long final TTL (forever when it is 0), results of unconfirmed transactions only briefly.
+func (s *Storage) SaveTransaction(ctx context.Context, network, hash string, trx models.CachedTransaction, final bool) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveTransaction()"),
+		slog.String("network", network),
+		slog.String("hash", hash),
+	)
+
+	data, err := json.Marshal(trx)
+	if err != nil {
+		logger.Error("failed to marshal transaction", slog.Any("error", err))
+		return
+	}
+
+	ttl := s.pendingTransactionTTL
+	if final {
+		ttl = s.finalTransactionTTL
+	}
+	err = s.client.Set(ctx, transactionKey(network, hash), data, ttl).Err()
+	if err != nil {
+		logger.Error("failed to save transaction to cache", slog.Any("error", err))
+		return
+	}
+
+	logger.Info("successfully saved transaction to cache", slog.Bool("final", final))
+	return
+}
+
+func (s *Storage) GetTransaction(ctx context.Context, network, hash string) (trx models.CachedTransaction, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetTransaction()"),
+		slog.String("network", network),
+		slog.String("hash", hash),
+	)
+
+	data, err := s.client.Get(ctx, transactionKey(network, hash)).Bytes()
+	if err == redis.Nil {
+		return trx, nil
+	}
+	if err != nil {
+		logger.Error("failed to get transaction from cache", slog.Any("error", err))
+		return
+	}
+
+	err = json.Unmarshal(data, &trx)
+	if err != nil {
+		logger.Error("failed to unmarshal transaction", slog.Any("error", err))
+		return
+	}
+
+	logger.Info("successfully loaded transaction from cache")
+	return
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func transferWatcherCursorKey(network string) string {
+	return "transfer_watcher:cursor:" + network
+}
+
+func (s *Storage) SaveTransferWatcherCursor(ctx context.Context, network string, block uint64) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveTransferWatcherCursor()"),
+		slog.String("network", network),
+	)
+
+	err = s.client.Set(ctx, transferWatcherCursorKey(network), block, 0).Err()
+	if err != nil {
+		logger.Error("failed to save transfer watcher cursor to cache", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) GetTransferWatcherCursor(ctx context.Context, network string) (block uint64, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetTransferWatcherCursor()"),
+		slog.String("network", network),
+	)
+
+	block, err = s.client.Get(ctx, transferWatcherCursorKey(network)).Uint64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		logger.Error("failed to get transfer watcher cursor from cache", slog.Any("error", err))
+		return
+	}
+	return
+}
@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// entries, their history and the list of watched addresses are changed in one transaction, so in
+// cluster mode every key of the watchlist shares the {watchlist} hash tag
+const watchlistKey = "{watchlist}:addresses"
+
+func watchedAddressID(network, address string) string {
+	return network + ":" + address
+}
+
+func watchedAddressKey(id string) string {
+	return "{watchlist}:watched_address:" + id
+}
+
+func balanceChangesKey(id string) string {
+	return "{watchlist}:balance_changes:" + id
+}
+
+// CreateWatchedAddress saves a new entry under its normalized address. Nothing is written and created
+// is false when the address is watched already.
+func (s *Storage) CreateWatchedAddress(ctx context.Context, watched models.WatchedAddress, address string) (created bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.CreateWatchedAddress()"),
+		slog.String("network", watched.Network),
+		slog.String("address", watched.Address),
+	)
+
+	data, err := json.Marshal(watched)
+	if err != nil {
+		logger.Error("failed to marshal watched address", slog.Any("error", err))
+		return
+	}
+
+	// the index already holds the ID of an existing entry, so adding it again is harmless
+	id := watchedAddressID(watched.Network, address)
+	var setCmd *redis.BoolCmd
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		setCmd = pipe.SetNX(ctx, watchedAddressKey(id), data, 0)
+		pipe.SAdd(ctx, watchlistKey, id)
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to save watched address to cache", slog.Any("error", err))
+		return
+	}
+	created = setCmd.Val()
+
+	logger.Debug("successfully saved watched address to cache", slog.Bool("created", created))
+	return
+}
+
+// UpdateWatchedAddress applies update to the current entry in a transaction, retried when the entry changes
+// concurrently, so update may run several times. Nothing is written when the entry does not exist, a deleted
+// entry is then not recreated, or update reports no change.
+func (s *Storage) UpdateWatchedAddress(ctx context.Context, network, address string, update func(watched *models.WatchedAddress) (changed bool)) (watched models.WatchedAddress, updated bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.UpdateWatchedAddress()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	key := watchedAddressKey(watchedAddressID(network, address))
+	apply := func(tx *redis.Tx) error {
+		watched, updated = models.WatchedAddress{}, false
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(data, &watched)
+		if err != nil {
+			return err
+		}
+		if watched.Balances == nil {
+			watched.Balances = map[string]string{}
+		}
+		if !update(&watched) {
+			return nil
+		}
+
+		data, err = json.Marshal(watched)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, 0)
+			return nil
+		})
+		updated = err == nil
+		return err
+	}
+
+	for attempt := 0; attempt < maxTxAttempts; attempt++ {
+		err = s.client.Watch(ctx, apply, key)
+		if err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		logger.Error("failed to update watched address", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) GetWatchedAddress(ctx context.Context, network, address string) (watched models.WatchedAddress, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetWatchedAddress()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	data, err := s.client.Get(ctx, watchedAddressKey(watchedAddressID(network, address))).Bytes()
+	if err == redis.Nil {
+		return watched, nil
+	}
+	if err != nil {
+		logger.Error("failed to get watched address from cache", slog.Any("error", err))
+		return
+	}
+
+	err = json.Unmarshal(data, &watched)
+	if err != nil {
+		logger.Error("failed to unmarshal watched address", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) ListWatchedAddresses(ctx context.Context) (list []models.WatchedAddress, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ListWatchedAddresses()"),
+	)
+
+	ids, err := s.client.SMembers(ctx, watchlistKey).Result()
+	if err != nil {
+		logger.Error("failed to list watched addresses", slog.Any("error", err))
+		return
+	}
+
+	list = []models.WatchedAddress{}
+	for _, id := range ids {
+		network, address, _ := strings.Cut(id, ":")
+		watched, err := s.GetWatchedAddress(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		if watched.Address == "" {
+			// the record is gone, drop the dangling index entry
+			s.client.SRem(ctx, watchlistKey, id)
+			continue
+		}
+		list = append(list, watched)
+	}
+	return
+}
+
+func (s *Storage) DeleteWatchedAddress(ctx context.Context, network, address string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.DeleteWatchedAddress()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	id := watchedAddressID(network, address)
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, watchedAddressKey(id), balanceChangesKey(id))
+		pipe.SRem(ctx, watchlistKey, id)
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to delete watched address from cache", slog.Any("error", err))
+		return
+	}
+
+	logger.Info("successfully deleted watched address from cache")
+	return
+}
+
+// AddBalanceChange prepends the change to the address history, keeping the latest balance_changes_size entries.
+func (s *Storage) AddBalanceChange(ctx context.Context, network, address string, change models.BalanceChange) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.AddBalanceChange()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		logger.Error("failed to marshal balance change", slog.Any("error", err))
+		return
+	}
+
+	key := balanceChangesKey(watchedAddressID(network, address))
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, data)
+		pipe.LTrim(ctx, key, 0, s.Config.Storages.Cache.BalanceChangesSize-1)
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to save balance change to cache", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) ListBalanceChanges(ctx context.Context, network, address string) (changes []models.BalanceChange, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ListBalanceChanges()"),
+		slog.String("network", network),
+		slog.String("address", address),
+	)
+
+	items, err := s.client.LRange(ctx, balanceChangesKey(watchedAddressID(network, address)), 0, -1).Result()
+	if err != nil {
+		logger.Error("failed to list balance changes", slog.Any("error", err))
+		return
+	}
+
+	changes = []models.BalanceChange{}
+	for _, item := range items {
+		var change models.BalanceChange
+		err = json.Unmarshal([]byte(item), &change)
+		if err != nil {
+			logger.Error("failed to unmarshal balance change", slog.Any("error", err))
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return
+}
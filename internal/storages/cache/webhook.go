@@ -0,0 +1,300 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// subscriptions with their address index and deliveries with their log and queue are changed in one
+// transaction, so in cluster mode every webhook key shares the {webhooks} hash tag
+const webhookDeliveryQueueKey = "{webhooks}:webhook_deliveries:queue"
+
+// leaseWebhookDeliveryScript moves the earliest due delivery to the lease deadline in one step,
+// so concurrent dispatchers never take the same delivery.
+var leaseWebhookDeliveryScript = redis.NewScript(`
+local due = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, 1)
+if #due == 0 then
+	return false
+end
+redis.call("ZADD", KEYS[1], ARGV[2], due[1])
+return due[1]
+`)
+
+func webhookSubscriptionKey(id string) string {
+	return "{webhooks}:webhook_subscription:" + id
+}
+
+func webhookAddressKey(address string) string {
+	return "{webhooks}:webhook_address:" + address
+}
+
+func webhookDeliveryKey(id string) string {
+	return "{webhooks}:webhook_delivery:" + id
+}
+
+func webhookDeliveryLogKey(subscriptionID string) string {
+	return "{webhooks}:webhook_deliveries:" + subscriptionID
+}
+
+// SaveWebhookSubscription saves the subscription and indexes it by its normalized addresses.
+func (s *Storage) SaveWebhookSubscription(ctx context.Context, subscription models.WebhookSubscription, addresses []string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveWebhookSubscription()"),
+		slog.String("subscription_id", subscription.ID),
+	)
+
+	data, err := json.Marshal(subscription)
+	if err != nil {
+		logger.Error("failed to marshal webhook subscription", slog.Any("error", err))
+		return
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, webhookSubscriptionKey(subscription.ID), data, 0)
+		for _, address := range addresses {
+			pipe.SAdd(ctx, webhookAddressKey(address), subscription.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to save webhook subscription to cache", slog.Any("error", err))
+		return
+	}
+
+	logger.Info("successfully saved webhook subscription to cache")
+	return
+}
+
+func (s *Storage) GetWebhookSubscription(ctx context.Context, id string) (subscription models.WebhookSubscription, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetWebhookSubscription()"),
+		slog.String("subscription_id", id),
+	)
+
+	data, err := s.client.Get(ctx, webhookSubscriptionKey(id)).Bytes()
+	if err == redis.Nil {
+		return subscription, nil
+	}
+	if err != nil {
+		logger.Error("failed to get webhook subscription from cache", slog.Any("error", err))
+		return
+	}
+
+	err = json.Unmarshal(data, &subscription)
+	if err != nil {
+		logger.Error("failed to unmarshal webhook subscription", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) DeleteWebhookSubscription(ctx context.Context, subscription models.WebhookSubscription, addresses []string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.DeleteWebhookSubscription()"),
+		slog.String("subscription_id", subscription.ID),
+	)
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, webhookSubscriptionKey(subscription.ID))
+		for _, address := range addresses {
+			pipe.SRem(ctx, webhookAddressKey(address), subscription.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to delete webhook subscription from cache", slog.Any("error", err))
+		return
+	}
+
+	logger.Info("successfully deleted webhook subscription from cache")
+	return
+}
+
+func (s *Storage) ListWebhookSubscriptionIDsByAddress(ctx context.Context, address string) (ids []string, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ListWebhookSubscriptionIDsByAddress()"),
+		slog.String("address", address),
+	)
+
+	ids, err = s.client.SMembers(ctx, webhookAddressKey(address)).Result()
+	if err != nil {
+		logger.Error("failed to list webhook subscriptions by address", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// CreateWebhookDelivery saves a new delivery, appends it to the subscription delivery log and schedules it.
+// Nothing is written when a delivery with the same ID exists, so the same event is queued once per subscription.
+func (s *Storage) CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) (created bool, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.CreateWebhookDelivery()"),
+		slog.String("delivery_id", delivery.ID),
+	)
+
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		logger.Error("failed to marshal webhook delivery", slog.Any("error", err))
+		return
+	}
+
+	key := webhookDeliveryKey(delivery.ID)
+	create := func(tx *redis.Tx) error {
+		created = false
+		exists, err := tx.Exists(ctx, key).Result()
+		if err != nil || exists > 0 {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, s.webhookDeliveryTTL)
+			pipe.LPush(ctx, webhookDeliveryLogKey(delivery.SubscriptionID), delivery.ID)
+			pipe.LTrim(ctx, webhookDeliveryLogKey(delivery.SubscriptionID), 0, s.Config.Service.Webhooks.DeliveryLogSize-1)
+			pipe.ZAdd(ctx, webhookDeliveryQueueKey, redis.Z{Score: float64(delivery.NextAttemptAt.Unix()), Member: delivery.ID})
+			return nil
+		})
+		created = err == nil
+		return err
+	}
+
+	for attempt := 0; attempt < maxTxAttempts; attempt++ {
+		err = s.client.Watch(ctx, create, key)
+		if err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		logger.Error("failed to create webhook delivery", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// SaveWebhookDelivery saves the outcome of a delivery attempt and reschedules pending deliveries for their next attempt.
+func (s *Storage) SaveWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.SaveWebhookDelivery()"),
+		slog.String("delivery_id", delivery.ID),
+	)
+
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		logger.Error("failed to marshal webhook delivery", slog.Any("error", err))
+		return
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, webhookDeliveryKey(delivery.ID), data, s.webhookDeliveryTTL)
+		if delivery.Status == models.WebhookDeliveryPending {
+			pipe.ZAdd(ctx, webhookDeliveryQueueKey, redis.Z{Score: float64(delivery.NextAttemptAt.Unix()), Member: delivery.ID})
+		} else {
+			// the outcome is final, which ends the lease of the delivery
+			pipe.ZRem(ctx, webhookDeliveryQueueKey, delivery.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to save webhook delivery to cache", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+func (s *Storage) GetWebhookDelivery(ctx context.Context, id string) (delivery models.WebhookDelivery, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.GetWebhookDelivery()"),
+		slog.String("delivery_id", id),
+	)
+
+	data, err := s.client.Get(ctx, webhookDeliveryKey(id)).Bytes()
+	if err == redis.Nil {
+		return delivery, nil
+	}
+	if err != nil {
+		logger.Error("failed to get webhook delivery from cache", slog.Any("error", err))
+		return
+	}
+
+	err = json.Unmarshal(data, &delivery)
+	if err != nil {
+		logger.Error("failed to unmarshal webhook delivery", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// ListWebhookDeliveries returns the most recent deliveries of the subscription, newest first.
+func (s *Storage) ListWebhookDeliveries(ctx context.Context, subscriptionID string) (deliveries []models.WebhookDelivery, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.ListWebhookDeliveries()"),
+		slog.String("subscription_id", subscriptionID),
+	)
+
+	ids, err := s.client.LRange(ctx, webhookDeliveryLogKey(subscriptionID), 0, -1).Result()
+	if err != nil {
+		logger.Error("failed to list webhook deliveries", slog.Any("error", err))
+		return
+	}
+
+	deliveries = []models.WebhookDelivery{}
+	for _, id := range ids {
+		delivery, err := s.GetWebhookDelivery(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if delivery.ID != "" {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return
+}
+
+// LeaseDueWebhookDelivery returns the ID of a delivery whose next attempt is due and moves it to leaseUntil
+// in the queue, or an empty ID when none is due. Each ID is returned once, even when several replicas call it
+// concurrently. A delivery whose dispatcher stops before saving the outcome is returned again once the lease is over.
+func (s *Storage) LeaseDueWebhookDelivery(ctx context.Context, now, leaseUntil time.Time) (id string, err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.LeaseDueWebhookDelivery()"),
+	)
+
+	id, err = leaseWebhookDeliveryScript.Run(ctx, s.client, []string{webhookDeliveryQueueKey}, now.Unix(), leaseUntil.Unix()).Text()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		logger.Error("failed to lease due webhook delivery", slog.Any("error", err))
+		return
+	}
+	return
+}
+
+// DequeueWebhookDelivery removes a delivery that will not be attempted again from the queue.
+func (s *Storage) DequeueWebhookDelivery(ctx context.Context, id string) (err error) {
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+		slog.String("func", "storage.cache.DequeueWebhookDelivery()"),
+		slog.String("delivery_id", id),
+	)
+
+	err = s.client.ZRem(ctx, webhookDeliveryQueueKey, id).Err()
+	if err != nil {
+		logger.Error("failed to remove webhook delivery from the queue", slog.Any("error", err))
+		return
+	}
+	return
+}
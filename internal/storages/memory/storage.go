@@ -0,0 +1,234 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/config"
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/internal/storages/cache"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	hitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "l1_cache_hits_total",
+		Help: "Number of wallet balance lookups served from the in-process cache.",
+	})
+	missesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "l1_cache_misses_total",
+		Help: "Number of wallet balance lookups passed to Redis.",
+	})
+	evictionsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "l1_cache_evictions_total",
+		Help: "Number of entries evicted from the in-process cache because of its size limit.",
+	})
+	invalidationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "l1_cache_invalidations_total",
+		Help: "Number of in-process cache entries invalidated by other replicas.",
+	})
+	entriesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "l1_cache_entries",
+		Help: "Number of entries in the in-process cache.",
+	})
+)
+
+// Storage is a bounded in-process LRU tier in front of the Redis wallet balance cache.
+// Only fresh balances are kept, for at most the configured TTL. Writes are announced
+// to the other replicas, which drop their copy of the entry.
+type Storage struct {
+	Config     *config.Config
+	cache      *cache.Storage
+	instanceID string
+	ttl        time.Duration
+	freshTTL   map[string]time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type entry struct {
+	key       string
+	balance   models.CachedBalance
+	expiresAt time.Time
+}
+
+func NewStorage(cfg *config.Config, cache *cache.Storage) (storage *Storage, err error) {
+	storage = &Storage{
+		Config:     cfg,
+		cache:      cache,
+		instanceID: uuid.New().String(),
+		ttl:        time.Duration(cfg.Storages.Memory.TTL) * time.Second,
+		freshTTL: map[string]time.Duration{
+			"ERC20": time.Duration(cfg.Storages.Cache.WalletBalanceTTL.Ethereum) * time.Second,
+			"TRC20": time.Duration(cfg.Storages.Cache.WalletBalanceTTL.Tron) * time.Second,
+		},
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+	return
+}
+
+func walletBalanceKey(network, contract, address string) string {
+	return network + ":" + contract + ":" + address
+}
+
+func (s *Storage) SaveWalletBalance(ctx context.Context, network, contract, address string, cached models.CachedBalance) (saved bool, err error) {
+	saved, err = s.cache.SaveWalletBalance(ctx, network, contract, address, cached)
+	key := walletBalanceKey(network, contract, address)
+	if err == nil && !saved {
+		// the balance was read before it was invalidated
+		s.delete(key)
+		return
+	}
+
+	// the in-process tier is updated even when Redis fails, during an outage it is the only one left
+	s.set(network, key, cached)
+	_ = s.cache.PublishCacheInvalidation(ctx, s.instanceID, key)
+	return
+}
+
+// GetWalletBalances serves the keys found in the in-process tier and reads the rest from Redis in one batch.
+func (s *Storage) GetWalletBalances(ctx context.Context, keys []models.WalletBalanceKey) (cached []models.CachedBalance, err error) {
+	cached = make([]models.CachedBalance, len(keys))
+	var missed []int
+	for i, key := range keys {
+		if balance, ok := s.get(walletBalanceKey(key.Network, key.Contract, key.Address)); ok {
+			hitsTotal.Inc()
+			cached[i] = balance
+			continue
+		}
+		missesTotal.Inc()
+		missed = append(missed, i)
+	}
+	if len(missed) == 0 {
+		return
+	}
+
+	missedKeys := make([]models.WalletBalanceKey, len(missed))
+	for j, i := range missed {
+		missedKeys[j] = keys[i]
+	}
+	loaded, err := s.cache.GetWalletBalances(ctx, missedKeys)
+	if err != nil {
+		return
+	}
+	for j, i := range missed {
+		cached[i] = loaded[j]
+		if loaded[j].Balance != "" && !loaded[j].Stale {
+			s.set(keys[i].Network, walletBalanceKey(keys[i].Network, keys[i].Contract, keys[i].Address), loaded[j])
+		}
+	}
+	return
+}
+
+func (s *Storage) InvalidateWalletBalance(ctx context.Context, network, contract, address string, blockNumber uint64) (err error) {
+	err = s.cache.InvalidateWalletBalance(ctx, network, contract, address, blockNumber)
+
+	// the in-process entry is evicted even when Redis fails, during an outage it is the only tier left
+	key := walletBalanceKey(network, contract, address)
+	s.delete(key)
+	_ = s.cache.PublishCacheInvalidation(ctx, s.instanceID, key)
+	return
+}
+
+func (s *Storage) GetWalletBalance(ctx context.Context, network, contract, address string) (cached models.CachedBalance, err error) {
+	key := walletBalanceKey(network, contract, address)
+	if cached, ok := s.get(key); ok {
+		hitsTotal.Inc()
+		return cached, nil
+	}
+	missesTotal.Inc()
+
+	cached, err = s.cache.GetWalletBalance(ctx, network, contract, address)
+	if err != nil {
+		return
+	}
+	if cached.Balance != "" && !cached.Stale {
+		s.set(network, key, cached)
+	}
+	return
+}
+
+// ListenInvalidations drops the entries written by other replicas until ctx is done.
+func (s *Storage) ListenInvalidations(ctx context.Context) {
+	slog.Info("starting L1 cache invalidation listener...")
+	for invalidation := range s.cache.SubscribeCacheInvalidations(ctx) {
+		if invalidation.InstanceID == s.instanceID {
+			continue
+		}
+		if s.delete(invalidation.Key) {
+			invalidationsTotal.Inc()
+		}
+	}
+	slog.Info("L1 cache invalidation listener stopped")
+}
+
+func (s *Storage) get(key string) (cached models.CachedBalance, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	e := element.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		s.remove(element)
+		return cached, false
+	}
+
+	s.lru.MoveToFront(element)
+	return e.balance, true
+}
+
+// set stores a fresh balance until the L1 TTL passes or the balance becomes stale, whichever comes first.
+func (s *Storage) set(network, key string, cached models.CachedBalance) {
+	expiresAt := time.Now().Add(s.ttl)
+	if staleAt := cached.CachedAt.Add(s.freshTTL[network]); staleAt.Before(expiresAt) {
+		expiresAt = staleAt
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		e := element.Value.(*entry)
+		e.balance = cached
+		e.expiresAt = expiresAt
+		s.lru.MoveToFront(element)
+		return
+	}
+
+	s.entries[key] = s.lru.PushFront(&entry{key: key, balance: cached, expiresAt: expiresAt})
+	for s.lru.Len() > s.Config.Storages.Memory.MaxEntries {
+		s.remove(s.lru.Back())
+		evictionsTotal.Inc()
+	}
+	entriesGauge.Set(float64(s.lru.Len()))
+}
+
+func (s *Storage) delete(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if ok {
+		s.remove(element)
+	}
+	return ok
+}
+
+// remove must be called with the mutex held.
+func (s *Storage) remove(element *list.Element) {
+	s.lru.Remove(element)
+	delete(s.entries, element.Value.(*entry).key)
+	entriesGauge.Set(float64(s.lru.Len()))
+}
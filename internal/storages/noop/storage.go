@@ -0,0 +1,95 @@
+package noop
+
+import (
+	"context"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+)
+
+// Storage is used in place of Redis when the cache is disabled. Writes are dropped and
+// every lookup is a miss, so all data is read from the nodes.
+type Storage struct{}
+
+func NewStorage() *Storage {
+	return &Storage{}
+}
+
+func (s *Storage) SaveWalletBalance(ctx context.Context, network, contract, address string, cached models.CachedBalance) (saved bool, err error) {
+	return
+}
+
+func (s *Storage) GetWalletBalance(ctx context.Context, network, contract, address string) (cached models.CachedBalance, err error) {
+	return
+}
+
+func (s *Storage) GetWalletBalances(ctx context.Context, keys []models.WalletBalanceKey) (cached []models.CachedBalance, err error) {
+	return make([]models.CachedBalance, len(keys)), nil
+}
+
+func (s *Storage) InvalidateWalletBalance(ctx context.Context, network, contract, address string, blockNumber uint64) (err error) {
+	return
+}
+
+func (s *Storage) SaveTransaction(ctx context.Context, network, hash string, trx models.CachedTransaction, final bool) (err error) {
+	return
+}
+
+func (s *Storage) GetTransaction(ctx context.Context, network, hash string) (trx models.CachedTransaction, err error) {
+	return
+}
+
+func (s *Storage) SaveBlacklistStatus(ctx context.Context, network, address, token string, blacklisted bool) (err error) {
+	return
+}
+
+func (s *Storage) GetBlacklistStatus(ctx context.Context, network, address, token string) (blacklisted, found bool, err error) {
+	return
+}
+
+// Locks are always granted, without Redis there are no other replicas to coordinate with.
+func (s *Storage) AcquireLock(ctx context.Context, name string, ttl time.Duration) (token string, ok bool, err error) {
+	return "", true, nil
+}
+
+func (s *Storage) ReleaseLock(ctx context.Context, name, token string) (err error) {
+	return
+}
+
+func (s *Storage) PublishEvent(ctx context.Context, event models.Event) (err error) {
+	return
+}
+
+func (s *Storage) PublishStreamEvent(ctx context.Context, event models.StreamEvent) (err error) {
+	return
+}
+
+// SubscribeStreamEvents returns a channel without events, closed when ctx is done.
+func (s *Storage) SubscribeStreamEvents(ctx context.Context) <-chan models.StreamEvent {
+	events := make(chan models.StreamEvent)
+	go func() {
+		<-ctx.Done()
+		close(events)
+	}()
+	return events
+}
+
+func (s *Storage) SaveScreeningEntry(ctx context.Context, entry models.ScreeningEntry, address string) (err error) {
+	return
+}
+
+func (s *Storage) GetScreeningEntry(ctx context.Context, network, address string) (entry models.ScreeningEntry, err error) {
+	return
+}
+
+func (s *Storage) ListScreeningEntries(ctx context.Context) (entries []models.ScreeningEntry, err error) {
+	return []models.ScreeningEntry{}, nil
+}
+
+func (s *Storage) DeleteScreeningEntry(ctx context.Context, network, address string) (err error) {
+	return
+}
+
+func (s *Storage) Status() models.ComponentStatus {
+	return models.ComponentStatus{Status: models.ComponentStatusDisabled}
+}
@@ -3,10 +3,14 @@ package storages
 import (
 	"github.com/OwodDEV/crypto-service/internal/config"
 	"github.com/OwodDEV/crypto-service/internal/storages/cache"
+	"github.com/OwodDEV/crypto-service/internal/storages/memory"
+	"github.com/OwodDEV/crypto-service/internal/storages/noop"
 )
 
 type Storages struct {
-	Cache *cache.Storage
+	Cache  *cache.Storage
+	Memory *memory.Storage
+	Noop   *noop.Storage
 }
 
 func NewStorages(cfg *config.Config) (storages *Storages, err error) {
@@ -17,5 +21,12 @@ func NewStorages(cfg *config.Config) (storages *Storages, err error) {
 		return
 	}
 
+	storages.Memory, err = memory.NewStorage(cfg, storages.Cache)
+	if err != nil {
+		return
+	}
+
+	storages.Noop = noop.NewStorage()
+
 	return
 }
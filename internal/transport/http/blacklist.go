@@ -0,0 +1,42 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Description Check whether the USDT issuer froze (blacklisted) the address
+// @Tags wallet
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param network path string true "Network" Enums(ethereum, tron)
+// @Param address path string true "Wallet Address" example(<br>ERC20 USDT: "0xe983fD1798689eee00c0Fb77e79B8f372DF41060", <br>TRC20 USDT: "TLSrrT5DiF5TkWPffJVQNwKE7SrctRCcpD")
+// @Success 200 {object} models.GetBlacklistStatusResp
+// @Failure 400 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Failure 502 {object} models.ErrorResp
+// @Router /api/{network}/wallet/{address}/blacklist [get]
+func (s *Server) GetBlacklistStatusHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	address := c.Params("address")
+	if address == "undefined" {
+		err = fmt.Errorf("%w: wallet address is empty", ErrInvalidRequest)
+		logger.Warn(err.Error())
+		return sendError(c, err)
+	}
+
+	resp, err := s.Service.GetBlacklistStatus(ctx, c.Params("network"), address)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseCacheControl reads the no-cache and max-age directives of the Cache-Control header.
+// The no_cache and max_age query parameters take precedence over the header.
+func parseCacheControl(c *fiber.Ctx) (cacheControl models.CacheControl, err error) {
+	for _, directive := range strings.Split(c.Get(fiber.HeaderCacheControl), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			cacheControl.NoCache = true
+		case "max-age":
+			cacheControl.MaxAge, err = parseMaxAge(value)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if value := c.Query("no_cache"); value != "" {
+		cacheControl.NoCache, err = strconv.ParseBool(value)
+		if err != nil {
+			err = fmt.Errorf("%w: invalid no_cache parameter", ErrInvalidRequest)
+			return
+		}
+	}
+	if value := c.Query("max_age"); value != "" {
+		cacheControl.MaxAge, err = parseMaxAge(value)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func parseMaxAge(value string) (maxAge *time.Duration, err error) {
+	seconds, err := strconv.ParseUint(strings.Trim(value, `"`), 10, 32)
+	if err != nil {
+		err = fmt.Errorf("%w: invalid max-age, expected a number of seconds", ErrInvalidRequest)
+		return
+	}
+	duration := time.Duration(seconds) * time.Second
+	return &duration, nil
+}
+
+// setFreshnessHeaders repeats the source of the value, when it was read and at which block in the response headers.
+func setFreshnessHeaders(c *fiber.Ctx, source string, cachedAt *time.Time, blockNumber uint64) {
+	c.Set("X-Data-Source", source)
+	if cachedAt != nil {
+		c.Set("X-Cached-At", cachedAt.UTC().Format(time.RFC3339))
+		c.Set(fiber.HeaderAge, strconv.FormatInt(int64(time.Since(*cachedAt).Seconds()), 10))
+	}
+	if blockNumber != 0 {
+		c.Set("X-Block-Number", strconv.FormatUint(blockNumber, 10))
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParseCacheControl(t *testing.T) {
+	seconds := func(n int) *time.Duration {
+		d := time.Duration(n) * time.Second
+		return &d
+	}
+
+	tests := []struct {
+		name        string
+		header      string
+		query       string
+		wantNoCache bool
+		wantMaxAge  *time.Duration
+		wantErr     bool
+	}{
+		{name: "no directives"},
+		{name: "no-cache", header: "no-cache", wantNoCache: true},
+		{name: "no-store", header: "no-store", wantNoCache: true},
+		{name: "max-age", header: "max-age=30", wantMaxAge: seconds(30)},
+		{name: "quoted max-age", header: `max-age="30"`, wantMaxAge: seconds(30)},
+		{name: "several directives", header: "private, No-Cache, max-age=0", wantNoCache: true, wantMaxAge: seconds(0)},
+		{name: "unknown directives are ignored", header: "must-revalidate, s-maxage=10"},
+		{name: "invalid max-age", header: "max-age=soon", wantErr: true},
+		{name: "negative max-age", header: "max-age=-1", wantErr: true},
+		{name: "no_cache parameter", query: "no_cache=true", wantNoCache: true},
+		{name: "no_cache parameter overrides the header", header: "no-cache", query: "no_cache=false"},
+		{name: "max_age parameter overrides the header", header: "max-age=30", query: "max_age=5", wantMaxAge: seconds(5)},
+		{name: "invalid no_cache parameter", query: "no_cache=maybe", wantErr: true},
+		{name: "invalid max_age parameter", query: "max_age=1.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				cacheControl, err := parseCacheControl(c)
+				if tt.wantErr {
+					if !errors.Is(err, ErrInvalidRequest) {
+						t.Errorf("err = %v, want ErrInvalidRequest", err)
+					}
+					return nil
+				}
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return nil
+				}
+				if cacheControl.NoCache != tt.wantNoCache {
+					t.Errorf("NoCache = %v, want %v", cacheControl.NoCache, tt.wantNoCache)
+				}
+				switch {
+				case tt.wantMaxAge == nil && cacheControl.MaxAge != nil:
+					t.Errorf("MaxAge = %v, want none", *cacheControl.MaxAge)
+				case tt.wantMaxAge != nil && (cacheControl.MaxAge == nil || *cacheControl.MaxAge != *tt.wantMaxAge):
+					t.Errorf("MaxAge = %v, want %v", cacheControl.MaxAge, *tt.wantMaxAge)
+				}
+				return nil
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/?"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderCacheControl, tt.header)
+			}
+			if _, err := app.Test(req); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+		})
+	}
+}
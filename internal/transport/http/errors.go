@@ -0,0 +1,101 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrInvalidRequest     = models.NewError(models.ErrInvalidArgument, "invalid_request", "invalid request")
+	ErrInvalidRequestBody = models.NewError(models.ErrInvalidArgument, "invalid_request_body", "invalid request body")
+	ErrAdminTokenRequired = models.NewError(models.ErrForbidden, "admin_token_required", "valid admin token required")
+
+	errInternal = models.NewError(errors.New("internal"), "internal_error", "internal error")
+)
+
+// errorStatuses maps the error kinds to the response status codes.
+var errorStatuses = []struct {
+	kind   error
+	status int
+}{
+	{models.ErrInvalidArgument, fiber.StatusBadRequest},
+	{models.ErrForbidden, fiber.StatusForbidden},
+	{models.ErrNotFound, fiber.StatusNotFound},
+	{models.ErrUnprocessable, fiber.StatusUnprocessableEntity},
+	{models.ErrUpstream, fiber.StatusBadGateway},
+	{models.ErrUnavailable, fiber.StatusServiceUnavailable},
+}
+
+// utilsErrors classifies the errors of pkg/utils, which does not depend on the models.
+var utilsErrors = []struct {
+	err      error
+	sentinel *models.Error
+}{
+	{utils.ErrUnknownAddress, models.NewError(models.ErrInvalidArgument, "invalid_address", utils.ErrUnknownAddress.Error())},
+	{utils.ErrUnknownHash, models.NewError(models.ErrInvalidArgument, "invalid_hash", utils.ErrUnknownHash.Error())},
+	{utils.ErrUnknownNetworkName, models.NewError(models.ErrInvalidArgument, "unknown_network", utils.ErrUnknownNetworkName.Error())},
+	{utils.ErrInvalidAmount, models.NewError(models.ErrInvalidArgument, "invalid_amount", utils.ErrInvalidAmount.Error())},
+	{utils.ErrTooManyDecimals, models.NewError(models.ErrInvalidArgument, "invalid_amount", utils.ErrTooManyDecimals.Error())},
+}
+
+// sendError responds with models.ErrorResp and the status code of the error kind.
+func sendError(c *fiber.Ctx, err error) error {
+	requestID, _ := c.UserContext().Value("request_id").(string)
+	status, resp := newErrorResp(requestID, err)
+	return c.Status(status).JSON(resp)
+}
+
+// newErrorResp classifies the error by its kind. Server side failures only expose the
+// message of the sentinel, their cause stays in the logs.
+func newErrorResp(requestID string, err error) (status int, resp models.ErrorResp) {
+	sentinel := errInternal
+	var typed *models.Error
+	if errors.As(err, &typed) {
+		sentinel = typed
+	} else {
+		for _, utilsErr := range utilsErrors {
+			if errors.Is(err, utilsErr.err) {
+				sentinel = utilsErr.sentinel
+				break
+			}
+		}
+	}
+
+	status = fiber.StatusInternalServerError
+	for _, errorStatus := range errorStatuses {
+		if errors.Is(sentinel, errorStatus.kind) {
+			status = errorStatus.status
+			break
+		}
+	}
+
+	resp = models.ErrorResp{
+		Code:      sentinel.Code,
+		Message:   sentinel.Message,
+		RequestID: requestID,
+	}
+	if status < fiber.StatusInternalServerError {
+		resp.Message = err.Error()
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		resp.Message = sentinel.Message
+		resp.Details = make(map[string]string, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			resp.Details[fieldErr.Field()] = fieldErr.Tag()
+		}
+	}
+	return
+}
+
+// newItemError reports the failure of a single item of a batch request.
+func newItemError(err error) *models.ItemError {
+	_, resp := newErrorResp("", err)
+	return &models.ItemError{Code: resp.Code, Message: resp.Message}
+}
@@ -1,10 +1,12 @@
 package http
 
 import (
-	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
+	"github.com/OwodDEV/crypto-service/internal/models"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -12,9 +14,17 @@ import (
 // @Tags wallet
 // @HeaderParam X-Request-ID string false "Optional request ID for tracing"
 // @Param address path string true "Wallet Address" example(<br>ERC20 USDT: "0xe983fD1798689eee00c0Fb77e79B8f372DF41060", <br>TRC20 USDT: "TLSrrT5DiF5TkWPffJVQNwKE7SrctRCcpD")
+// @Param Cache-Control header string false "no-cache for a live value, max-age=<seconds> for a value read at most that long ago"
+// @Param no_cache query bool false "Same as Cache-Control: no-cache"
+// @Param max_age query int false "Same as Cache-Control: max-age, in seconds"
 // @Success 200 {object} models.GetWalletResp
-// @Failure 400
-// @Failure 500
+// @Header 200 {string} X-Data-Source "cache or live"
+// @Header 200 {string} X-Cached-At "When the value was read from the node"
+// @Header 200 {integer} X-Block-Number "Block the balance was read at, omitted when unknown"
+// @Failure 400 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Failure 502 {object} models.ErrorResp
+// @Failure 503 {object} models.ErrorResp
 // @Router /api/wallet/{address} [get]
 func (s *Server) GetWalletHandler(c *fiber.Ctx) (err error) {
 	ctx := c.UserContext()
@@ -24,14 +34,71 @@ func (s *Server) GetWalletHandler(c *fiber.Ctx) (err error) {
 
 	address := c.Params("address")
 	if address == "undefined" {
-		err = errors.New("wallet address is empty")
+		err = fmt.Errorf("%w: wallet address is empty", ErrInvalidRequest)
+		logger.Warn(err.Error())
+		return sendError(c, err)
+	}
+
+	cacheControl, err := parseCacheControl(c)
+	if err != nil {
+		logger.Warn(err.Error())
+		return sendError(c, err)
+	}
+
+	resp, err := s.Service.GetWallet(ctx, address, cacheControl)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	setFreshnessHeaders(c, resp.Source, resp.CachedAt, resp.BlockNumber)
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
+
+// @Description Get USDT balances of several addresses. Cached balances are served in one batch, the rest is read from the nodes concurrently. Failed items carry an error instead of the balance.
+// @Tags wallet
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param request body models.GetWalletBalancesReq true "Balances to look up. `network` is ethereum or tron, detected from the address when omitted"
+// @Param Cache-Control header string false "no-cache for live values, max-age=<seconds> for values read at most that long ago"
+// @Param no_cache query bool false "Same as Cache-Control: no-cache"
+// @Param max_age query int false "Same as Cache-Control: max-age, in seconds"
+// @Success 200 {object} models.GetWalletBalancesResp
+// @Failure 400 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/wallets/balances [post]
+func (s *Server) GetWalletBalancesHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	var req models.GetWalletBalancesReq
+	err = c.BodyParser(&req)
+	if err != nil {
+		logger.Warn("failed to parse request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+	err = s.Validate.Struct(req)
+	if err != nil {
+		logger.Warn("invalid request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	cacheControl, err := parseCacheControl(c)
+	if err != nil {
 		logger.Warn(err.Error())
-		return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		return sendError(c, err)
 	}
 
-	resp, err := s.Service.GetWallet(ctx, address)
+	resp, err := s.Service.GetWalletBalances(ctx, req.Items, cacheControl)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		return sendError(c, err)
+	}
+	for i := range resp.Items {
+		if resp.Items[i].Err != nil {
+			resp.Items[i].Error = newItemError(resp.Items[i].Err)
+		}
 	}
 
 	c.JSON(resp)
@@ -43,9 +110,19 @@ func (s *Server) GetWalletHandler(c *fiber.Ctx) (err error) {
 // @Tags transaction
 // @HeaderParam X-Request-ID string false "Optional request ID for tracing"
 // @Param hash path string true "Transaction Hash" example(<br>ERC20 USDT: "0xec1d31abdcb80d24d0d823b35f93ed30c837d26364928e3b1b97b3c1cdd7fe69", <br>TRC20 USDT: "d6d1cc1ab403bc0febfb69d7be0bd8bd2fc03e2a03c4e2bdfd74560bd66109be")
+// @Param Cache-Control header string false "no-cache for a live value, max-age=<seconds> for a value read at most that long ago"
+// @Param no_cache query bool false "Same as Cache-Control: no-cache"
+// @Param max_age query int false "Same as Cache-Control: max-age, in seconds"
 // @Success 200 {object} models.GetTransactionResp
-// @Failure 400
-// @Failure 500
+// @Header 200 {string} X-Data-Source "cache or live"
+// @Header 200 {string} X-Cached-At "When the value was read from the node"
+// @Header 200 {integer} X-Block-Number "Latest block known when the value was read"
+// @Failure 400 {object} models.ErrorResp
+// @Failure 404 {object} models.ErrorResp
+// @Failure 422 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Failure 502 {object} models.ErrorResp
+// @Failure 503 {object} models.ErrorResp
 // @Router /api/transaction/{hash} [get]
 func (s *Server) GetTransactionHandler(c *fiber.Ctx) (err error) {
 	ctx := c.UserContext()
@@ -55,14 +132,71 @@ func (s *Server) GetTransactionHandler(c *fiber.Ctx) (err error) {
 
 	hash := c.Params("hash")
 	if hash == "undefined" {
-		err = errors.New("transaction hash is required")
+		err = fmt.Errorf("%w: transaction hash is required", ErrInvalidRequest)
 		logger.Warn(err.Error())
-		return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		return sendError(c, err)
 	}
 
-	resp, err := s.Service.GetTransaction(ctx, hash)
+	cacheControl, err := parseCacheControl(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		logger.Warn(err.Error())
+		return sendError(c, err)
+	}
+
+	resp, err := s.Service.GetTransaction(ctx, hash, cacheControl)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	setFreshnessHeaders(c, resp.Source, resp.CachedAt, resp.BlockNumber)
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
+
+// @Description Look up USDT transactions of several hashes across networks. The network of each hash is detected and the transactions are fetched concurrently. Failed hashes carry an error instead of the transaction.
+// @Tags transaction
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param request body models.LookupTransactionsReq true "Transaction hashes"
+// @Param Cache-Control header string false "no-cache for live values, max-age=<seconds> for values read at most that long ago"
+// @Param no_cache query bool false "Same as Cache-Control: no-cache"
+// @Param max_age query int false "Same as Cache-Control: max-age, in seconds"
+// @Success 200 {object} models.LookupTransactionsResp
+// @Failure 400 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/transactions/lookup [post]
+func (s *Server) LookupTransactionsHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	var req models.LookupTransactionsReq
+	err = c.BodyParser(&req)
+	if err != nil {
+		logger.Warn("failed to parse request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+	err = s.Validate.Struct(req)
+	if err != nil {
+		logger.Warn("invalid request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	cacheControl, err := parseCacheControl(c)
+	if err != nil {
+		logger.Warn(err.Error())
+		return sendError(c, err)
+	}
+
+	resp, err := s.Service.LookupTransactions(ctx, req.Hashes, cacheControl)
+	if err != nil {
+		return sendError(c, err)
+	}
+	for i := range resp.Items {
+		if resp.Items[i].Err != nil {
+			resp.Items[i].Error = newItemError(resp.Items[i].Err)
+		}
 	}
 
 	c.JSON(resp)
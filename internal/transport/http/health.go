@@ -0,0 +1,19 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Description Get the service health. The status is "degraded" while Redis is unavailable and requests bypass the cache
+// @Tags health
+// @Success 200 {object} models.HealthResp
+// @Router /health [get]
+func (s *Server) GetHealthHandler(c *fiber.Ctx) (err error) {
+	resp := s.Service.GetHealth(c.UserContext())
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"log/slog"
 
 	"github.com/OwodDEV/crypto-service/internal/config"
@@ -8,6 +9,7 @@ import (
 	"github.com/OwodDEV/crypto-service/internal/service"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/swagger"
@@ -21,6 +23,10 @@ type Server struct {
 	Config   *config.Config
 	Validate *validator.Validate
 	router   *fiber.App
+
+	hub        *streamHub
+	streamCtx  context.Context
+	stopStream context.CancelFunc
 }
 
 func NewServer(srv *service.Service, mtr *metrics.Metrics, cfg *config.Config) (s *Server, err error) {
@@ -29,7 +35,9 @@ func NewServer(srv *service.Service, mtr *metrics.Metrics, cfg *config.Config) (
 		Metrics:  mtr,
 		Config:   cfg,
 		Validate: validator.New(),
+		hub:      newStreamHub(),
 	}
+	s.streamCtx, s.stopStream = context.WithCancel(context.Background())
 	return
 }
 
@@ -51,7 +59,19 @@ func (s *Server) Run(errCh chan<- error) {
 
 	// api routes
 	s.router.Get("/api/wallet/:address", s.GetWalletHandler)
+	s.router.Post("/api/wallets/balances", s.GetWalletBalancesHandler)
 	s.router.Get("/api/transaction/:hash", s.GetTransactionHandler)
+	s.router.Post("/api/transactions/lookup", s.LookupTransactionsHandler)
+	s.router.Get("/api/:network/wallet/:address/blacklist", s.GetBlacklistStatusHandler)
+	s.router.Get("/api/tron/account/:address/resources", s.GetTronAccountResourcesHandler)
+
+	// features kept in Redis are only served with the cache enabled
+	if s.Config.Storages.Cache.Enabled {
+		s.registerStatefulRoutes()
+	}
+
+	// health
+	s.router.Get("/health", s.GetHealthHandler)
 
 	// swagger
 	s.router.Get("/swagger/*", swagger.HandlerDefault)
@@ -59,6 +79,9 @@ func (s *Server) Run(errCh chan<- error) {
 	// metrics
 	s.router.Get("/metrics", adaptor.HTTPHandler(s.Metrics.PrometheusHandler()))
 
+	// stream events
+	go s.hub.run(s.Service.StreamEvents(s.streamCtx))
+
 	// startup
 	slog.Info(
 		"starting the HTTP server",
@@ -71,7 +94,32 @@ func (s *Server) Run(errCh chan<- error) {
 	}
 }
 
+// registerStatefulRoutes registers the routes of the features that keep their state in Redis.
+func (s *Server) registerStatefulRoutes() {
+	s.router.Post("/api/invoices", s.CreateInvoiceHandler)
+	s.router.Get("/api/invoices/:id", s.GetInvoiceHandler)
+	s.router.Post("/api/webhooks", s.CreateWebhookSubscriptionHandler)
+	s.router.Get("/api/webhooks/:id", s.GetWebhookSubscriptionHandler)
+	s.router.Delete("/api/webhooks/:id", s.DeleteWebhookSubscriptionHandler)
+	s.router.Get("/api/webhooks/:id/deliveries", s.ListWebhookDeliveriesHandler)
+	s.router.Post("/api/watchlist", s.CreateWatchedAddressHandler)
+	s.router.Get("/api/watchlist", s.ListWatchedAddressesHandler)
+	s.router.Get("/api/watchlist/:network/:address", s.GetWatchedAddressHandler)
+	s.router.Put("/api/watchlist/:network/:address", s.UpdateWatchedAddressHandler)
+	s.router.Delete("/api/watchlist/:network/:address", s.DeleteWatchedAddressHandler)
+	s.router.Get("/api/watchlist/:network/:address/balance-changes", s.ListBalanceChangesHandler)
+	s.router.Post("/api/screening/addresses", s.AdminMiddleware(), s.CreateScreeningEntryHandler)
+	s.router.Get("/api/screening/addresses", s.ListScreeningEntriesHandler)
+	s.router.Delete("/api/screening/addresses/:network/:address", s.AdminMiddleware(), s.DeleteScreeningEntryHandler)
+	s.router.Get("/api/stream", s.StreamUpgradeMiddleware(), websocket.New(s.StreamHandler))
+	s.router.Post("/api/:network/tracked-transactions", s.TrackTransactionHandler)
+	s.router.Get("/api/:network/tracked-transactions/:hash", s.GetTrackedTransactionHandler)
+}
+
 func (s *Server) Shutdown() {
 	slog.Info("shutting down HTTP server...")
+	// hijacked WebSocket connections would otherwise keep the server from shutting down
+	s.stopStream()
+	s.hub.closeAll()
 	s.router.Shutdown()
 }
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Description Create a payment invoice with an assigned receiving address
+// @Tags invoices
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param request body models.CreateInvoiceReq true "Invoice. `network` is ethereum or tron, `expires_in` is in seconds"
+// @Success 201 {object} models.Invoice
+// @Failure 400 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Failure 503 {object} models.ErrorResp
+// @Router /api/invoices [post]
+func (s *Server) CreateInvoiceHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	var req models.CreateInvoiceReq
+	err = c.BodyParser(&req)
+	if err != nil {
+		logger.Warn("failed to parse request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+	err = s.Validate.Struct(req)
+	if err != nil {
+		logger.Warn("invalid request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	resp, err := s.Service.CreateInvoice(ctx, req)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusCreated)
+	return
+}
+
+// @Description Get a payment invoice and its payments
+// @Tags invoices
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} models.Invoice
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/invoices/{id} [get]
+func (s *Server) GetInvoiceHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	resp, err := s.Service.GetInvoice(ctx, c.Params("id"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"crypto/subtle"
 	"log/slog"
 	"time"
 
@@ -22,6 +23,25 @@ func (s *Server) TraceMiddleware() fiber.Handler {
 	}
 }
 
+// AdminMiddleware restricts the route to requests with the "Authorization: Bearer <admin token>" header.
+// The route is closed to everyone when no admin token is configured.
+func (s *Server) AdminMiddleware() fiber.Handler {
+	expected := []byte("Bearer " + s.Config.Transport.HTTP.AdminToken)
+	return func(c *fiber.Ctx) error {
+		token := []byte(c.Get(fiber.HeaderAuthorization))
+		if s.Config.Transport.HTTP.AdminToken == "" || subtle.ConstantTimeCompare(token, expected) != 1 {
+			requestID, _ := c.UserContext().Value("request_id").(string)
+			slog.Warn("admin route requested without a valid admin token",
+				slog.String("request_id", requestID),
+				slog.String("path", c.Path()),
+				slog.String("remote_ip", c.IP()),
+			)
+			return sendError(c, ErrAdminTokenRequired)
+		}
+		return c.Next()
+	}
+}
+
 func (s *Server) RequestLoggerMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
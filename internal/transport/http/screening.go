@@ -0,0 +1,91 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Description Add an address to the internal screening list. Requires the admin token
+// @Tags screening
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @HeaderParam Authorization string true "Bearer <admin token>"
+// @Param request body models.CreateScreeningEntryReq true "Blocked address. `network` is ethereum or tron, detected by the address when empty"
+// @Success 201 {object} models.ScreeningEntry
+// @Failure 400 {object} models.ErrorResp
+// @Failure 403 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/screening/addresses [post]
+func (s *Server) CreateScreeningEntryHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	var req models.CreateScreeningEntryReq
+	err = c.BodyParser(&req)
+	if err != nil {
+		logger.Warn("failed to parse request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+	err = s.Validate.Struct(req)
+	if err != nil {
+		logger.Warn("invalid request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	resp, err := s.Service.CreateScreeningEntry(ctx, req)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusCreated)
+	return
+}
+
+// @Description List the internal screening list. Entries loaded from the list files are not included
+// @Tags screening
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Success 200 {array} models.ScreeningEntry
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/screening/addresses [get]
+func (s *Server) ListScreeningEntriesHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	resp, err := s.Service.ListScreeningEntries(ctx)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
+
+// @Description Remove an address from the internal screening list. Requires the admin token
+// @Tags screening
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @HeaderParam Authorization string true "Bearer <admin token>"
+// @Param network path string true "Network" Enums(ethereum, tron)
+// @Param address path string true "Wallet Address"
+// @Success 204
+// @Failure 400 {object} models.ErrorResp
+// @Failure 403 {object} models.ErrorResp
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/screening/addresses/{network}/{address} [delete]
+func (s *Server) DeleteScreeningEntryHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	err = s.Service.DeleteScreeningEntry(ctx, c.Params("network"), c.Params("address"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,400 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+	"github.com/OwodDEV/crypto-service/pkg/utils"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	streamWriteTimeout = 10 * time.Second
+	// balance lookups run concurrently for each subscribe message
+	streamSnapshotConcurrency = 8
+)
+
+// streamHub keeps the WebSocket clients of this replica and routes stream events to their subscriptions.
+type streamHub struct {
+	mu          sync.Mutex
+	closed      bool
+	clients     map[*streamClient]struct{}
+	subscribers map[string]map[*streamClient]struct{}
+}
+
+type streamClient struct {
+	conn      *websocket.Conn
+	send      chan models.StreamMessage
+	done      chan struct{}
+	closeOnce sync.Once
+	addresses map[string]string        // normalized address -> address as subscribed
+	balances  map[string]streamBalance // normalized address + token -> last sent balance
+	snapshots sync.WaitGroup           // balance snapshots still being sent
+}
+
+// streamBalance is a balance sent to the client with the time it was read at.
+type streamBalance struct {
+	balance string
+	readAt  time.Time
+}
+
+func newStreamHub() *streamHub {
+	return &streamHub{
+		clients:     make(map[*streamClient]struct{}),
+		subscribers: make(map[string]map[*streamClient]struct{}),
+	}
+}
+
+func (h *streamHub) register(client *streamClient) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return false
+	}
+	h.clients[client] = struct{}{}
+	return true
+}
+
+func (h *streamHub) unregister(client *streamClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.clients, client)
+	for address := range client.addresses {
+		h.removeSubscriber(address, client)
+	}
+}
+
+// subscribe adds the addresses to the client subscriptions and returns the newly added ones.
+func (h *streamHub) subscribe(client *streamClient, addresses map[string]string, limit int) (added map[string]string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	added = make(map[string]string)
+	for normalized, address := range addresses {
+		if _, ok := client.addresses[normalized]; !ok {
+			added[normalized] = address
+		}
+	}
+	if len(client.addresses)+len(added) > limit {
+		return nil, fmt.Errorf("subscription limit of %d addresses exceeded", limit)
+	}
+
+	for normalized, address := range added {
+		client.addresses[normalized] = address
+		if h.subscribers[normalized] == nil {
+			h.subscribers[normalized] = make(map[*streamClient]struct{})
+		}
+		h.subscribers[normalized][client] = struct{}{}
+	}
+	return
+}
+
+func (h *streamHub) unsubscribe(client *streamClient, addresses map[string]string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for normalized := range addresses {
+		delete(client.addresses, normalized)
+		for key := range client.balances {
+			if len(key) > len(normalized) && key[:len(normalized)+1] == normalized+":" {
+				delete(client.balances, key)
+			}
+		}
+		h.removeSubscriber(normalized, client)
+	}
+}
+
+func (h *streamHub) removeSubscriber(address string, client *streamClient) {
+	delete(h.subscribers[address], client)
+	if len(h.subscribers[address]) == 0 {
+		delete(h.subscribers, address)
+	}
+}
+
+// run forwards stream events to the subscribed clients until the events channel is closed.
+func (h *streamHub) run(events <-chan models.StreamEvent) {
+	for event := range events {
+		h.broadcast(event)
+	}
+}
+
+func (h *streamHub) broadcast(event models.StreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	notified := make(map[*streamClient]struct{})
+	for _, address := range event.Addresses {
+		for client := range h.subscribers[address] {
+			if _, ok := notified[client]; ok {
+				continue
+			}
+			notified[client] = struct{}{}
+
+			msg := models.StreamMessage{
+				Type:     event.Type,
+				Address:  client.addresses[address],
+				Network:  event.Network,
+				Token:    event.Token,
+				Balance:  event.Balance,
+				Transfer: event.Transfer,
+				Time:     event.Time,
+			}
+			if event.Type == models.StreamEventBalance {
+				key := address + ":" + event.Token
+				if client.balances[key].balance == event.Balance {
+					continue
+				}
+				client.balances[key] = streamBalance{balance: event.Balance, readAt: event.Time}
+			}
+			client.push(msg)
+		}
+	}
+}
+
+// pushBalance records and sends a balance message outside of broadcast. It is recorded and queued under
+// the hub lock, like broadcast does, so the client never gets it after a balance read later. It is dropped
+// when the client has unsubscribed from the address meanwhile or was sent a balance read later.
+func (h *streamHub) pushBalance(client *streamClient, address string, msg models.StreamMessage, readAt time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := client.addresses[address]; !ok {
+		return
+	}
+	key := address + ":" + msg.Token
+	if client.balances[key].readAt.After(readAt) {
+		return
+	}
+	client.balances[key] = streamBalance{balance: msg.Balance, readAt: readAt}
+	client.push(msg)
+}
+
+// closeAll disconnects every client with a going-away close frame and rejects new ones.
+func (h *streamHub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for client := range h.clients {
+		_ = client.conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutdown"),
+			time.Now().Add(time.Second),
+		)
+		client.close()
+	}
+}
+
+// push queues a message for the client, disconnecting clients that do not keep up.
+func (c *streamClient) push(msg models.StreamMessage) {
+	select {
+	case c.send <- msg:
+	case <-c.done:
+	default:
+		slog.Warn("disconnecting slow WebSocket client", slog.String("remote_ip", c.conn.IP()))
+		c.close()
+	}
+}
+
+func (c *streamClient) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+// StreamUpgradeMiddleware rejects plain HTTP requests to the stream endpoint
+// and passes the request ID to the WebSocket connection.
+func (s *Server) StreamUpgradeMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return c.Status(fiber.StatusUpgradeRequired).SendString("websocket upgrade required")
+		}
+
+		c.Locals("request_id", c.UserContext().Value("request_id"))
+		return c.Next()
+	}
+}
+
+// @Description WebSocket stream of balance changes and token transfers.
+// @Description Send {"action": "subscribe"|"unsubscribe", "addresses": [...]} to manage subscriptions.
+// @Description The server pushes models.StreamMessage objects of type "balance", "transfer", "subscribed", "unsubscribed" and "error",
+// @Description and sends ping frames every heartbeat interval. Clients that do not answer pings are disconnected.
+// @Tags stream
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Success 101 {object} models.StreamMessage
+// @Failure 426
+// @Router /api/stream [get]
+func (s *Server) StreamHandler(conn *websocket.Conn) {
+	requestID, _ := conn.Locals("request_id").(string)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), "request_id", requestID))
+	defer cancel()
+	logger := slog.With(
+		slog.String("request_id", requestID),
+		slog.String("remote_ip", conn.IP()),
+	)
+
+	client := &streamClient{
+		conn:      conn,
+		send:      make(chan models.StreamMessage, s.Config.Transport.WebSocket.SendBufferSize),
+		done:      make(chan struct{}),
+		addresses: make(map[string]string),
+		balances:  make(map[string]streamBalance),
+	}
+	if !s.hub.register(client) {
+		conn.Close()
+		return
+	}
+	logger.Info("WebSocket client connected")
+
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		s.writeStream(client)
+	}()
+
+	s.readStream(ctx, client)
+
+	// the connection is released when the handler returns, so the writer must be stopped first
+	s.hub.unregister(client)
+	client.close()
+	cancel()
+	client.snapshots.Wait()
+	<-writerDone
+	logger.Info("WebSocket client disconnected")
+}
+
+func (s *Server) readStream(ctx context.Context, client *streamClient) {
+	heartbeat := time.Duration(s.Config.Transport.WebSocket.HeartbeatInterval) * time.Second
+	client.conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+	client.conn.SetPongHandler(func(string) error {
+		return client.conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+	})
+
+	for {
+		var req models.StreamRequest
+		err := client.conn.ReadJSON(&req)
+		if err != nil {
+			return
+		}
+
+		addresses, err := normalizeStreamAddresses(req.Addresses)
+		if err != nil {
+			client.push(models.StreamMessage{Type: models.StreamMessageError, Error: err.Error(), Time: time.Now().UTC()})
+			continue
+		}
+
+		switch req.Action {
+		case models.StreamActionSubscribe:
+			added, err := s.hub.subscribe(client, addresses, s.Config.Transport.WebSocket.MaxSubscriptions)
+			if err != nil {
+				client.push(models.StreamMessage{Type: models.StreamMessageError, Error: err.Error(), Time: time.Now().UTC()})
+				continue
+			}
+			client.push(models.StreamMessage{Type: models.StreamMessageSubscribed, Addresses: req.Addresses, Time: time.Now().UTC()})
+			// the lookups run off the read loop, so pongs keep extending the read deadline
+			client.snapshots.Add(1)
+			go func() {
+				defer client.snapshots.Done()
+				s.sendBalanceSnapshots(ctx, client, added)
+			}()
+		case models.StreamActionUnsubscribe:
+			s.hub.unsubscribe(client, addresses)
+			client.push(models.StreamMessage{Type: models.StreamMessageUnsubscribed, Addresses: req.Addresses, Time: time.Now().UTC()})
+		default:
+			client.push(models.StreamMessage{Type: models.StreamMessageError, Error: "unknown action", Time: time.Now().UTC()})
+		}
+	}
+}
+
+func (s *Server) writeStream(client *streamClient) {
+	ticker := time.NewTicker(time.Duration(s.Config.Transport.WebSocket.HeartbeatInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-client.done:
+			return
+		case msg := <-client.send:
+			client.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			err := client.conn.WriteJSON(msg)
+			if err != nil {
+				client.close()
+				return
+			}
+		case <-ticker.C:
+			err := client.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout))
+			if err != nil {
+				client.close()
+				return
+			}
+		}
+	}
+}
+
+// sendBalanceSnapshots sends the current balance of newly subscribed addresses,
+// so later balance messages are only sent on change.
+func (s *Server) sendBalanceSnapshots(ctx context.Context, client *streamClient, addresses map[string]string) {
+	var group errgroup.Group
+	group.SetLimit(streamSnapshotConcurrency)
+	for normalized, address := range addresses {
+		group.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			s.sendBalanceSnapshot(ctx, client, normalized, address)
+			return nil
+		})
+	}
+	_ = group.Wait()
+}
+
+func (s *Server) sendBalanceSnapshot(ctx context.Context, client *streamClient, normalized, address string) {
+	network, _ := utils.DetectNetworkByAddr(address)
+	readStart := time.Now().UTC()
+	resp, err := s.Service.GetWallet(ctx, address, models.CacheControl{})
+	if err != nil {
+		client.push(models.StreamMessage{Type: models.StreamMessageError, Address: address, Error: err.Error(), Time: time.Now().UTC()})
+		return
+	}
+
+	// a cached balance is as old as its read from the node
+	readAt := readStart
+	if resp.CachedAt != nil {
+		readAt = *resp.CachedAt
+	}
+	s.hub.pushBalance(client, normalized, models.StreamMessage{
+		Type:    models.StreamEventBalance,
+		Address: address,
+		Network: network,
+		Token:   "USDT",
+		Balance: resp.Balance,
+		Time:    time.Now().UTC(),
+	}, readAt)
+}
+
+func normalizeStreamAddresses(addresses []string) (normalized map[string]string, err error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("addresses are required")
+	}
+
+	normalized = make(map[string]string)
+	for _, address := range addresses {
+		network, err := utils.DetectNetworkByAddr(address)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, address)
+		}
+		normalized[utils.NormalizeAddress(network, address)] = address
+	}
+	return
+}
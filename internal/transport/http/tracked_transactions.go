@@ -0,0 +1,71 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Description Register an outgoing transaction for lifecycle tracking
+// @Tags tracked-transactions
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param network path string true "Network" Enums(ethereum, tron)
+// @Param request body models.TrackTransactionReq true "Transaction to track. `replaces` links a speed-up or cancel transaction to the original one"
+// @Success 201 {object} models.TrackedTransaction
+// @Failure 400 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/{network}/tracked-transactions [post]
+func (s *Server) TrackTransactionHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	var req models.TrackTransactionReq
+	err = c.BodyParser(&req)
+	if err != nil {
+		logger.Warn("failed to parse request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+	err = s.Validate.Struct(req)
+	if err != nil {
+		logger.Warn("invalid request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	resp, err := s.Service.TrackTransaction(ctx, c.Params("network"), req)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusCreated)
+	return
+}
+
+// @Description Get the state and state history of a tracked transaction
+// @Tags tracked-transactions
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param network path string true "Network" Enums(ethereum, tron)
+// @Param hash path string true "Transaction Hash"
+// @Success 200 {object} models.TrackedTransaction
+// @Failure 400 {object} models.ErrorResp
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/{network}/tracked-transactions/{hash} [get]
+func (s *Server) GetTrackedTransactionHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	resp, err := s.Service.GetTrackedTransaction(ctx, c.Params("network"), c.Params("hash"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Description Get Tron account resources: bandwidth, energy, Stake 2.0 staked TRX, delegated resources and activation state
+// @Tags tron
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param address path string true "Tron Address" example(TLSrrT5DiF5TkWPffJVQNwKE7SrctRCcpD)
+// @Success 200 {object} models.GetTronAccountResourcesResp
+// @Failure 400 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Failure 502 {object} models.ErrorResp
+// @Router /api/tron/account/{address}/resources [get]
+func (s *Server) GetTronAccountResourcesHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	address := c.Params("address")
+	if address == "undefined" {
+		err = fmt.Errorf("%w: wallet address is empty", ErrInvalidRequest)
+		logger.Warn(err.Error())
+		return sendError(c, err)
+	}
+
+	resp, err := s.Service.GetTronAccountResources(ctx, address)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
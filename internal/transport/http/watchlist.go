@@ -0,0 +1,173 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Description Add an address to the watchlist
+// @Tags watchlist
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param request body models.CreateWatchedAddressReq true "Watched address. `network` is ethereum or tron"
+// @Success 201 {object} models.WatchedAddress
+// @Failure 400 {object} models.ErrorResp
+// @Failure 422 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/watchlist [post]
+func (s *Server) CreateWatchedAddressHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	var req models.CreateWatchedAddressReq
+	err = c.BodyParser(&req)
+	if err != nil {
+		logger.Warn("failed to parse request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+	err = s.Validate.Struct(req)
+	if err != nil {
+		logger.Warn("invalid request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	resp, err := s.Service.CreateWatchedAddress(ctx, req)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusCreated)
+	return
+}
+
+// @Description List watched addresses
+// @Tags watchlist
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Success 200 {array} models.WatchedAddress
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/watchlist [get]
+func (s *Server) ListWatchedAddressesHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	resp, err := s.Service.ListWatchedAddresses(ctx)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
+
+// @Description Get a watched address with its last known balances
+// @Tags watchlist
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param network path string true "Network" Enums(ethereum, tron)
+// @Param address path string true "Wallet Address"
+// @Success 200 {object} models.WatchedAddress
+// @Failure 400 {object} models.ErrorResp
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/watchlist/{network}/{address} [get]
+func (s *Server) GetWatchedAddressHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	resp, err := s.Service.GetWatchedAddress(ctx, c.Params("network"), c.Params("address"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
+
+// @Description Update the tokens, label and owner reference of a watched address
+// @Tags watchlist
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param network path string true "Network" Enums(ethereum, tron)
+// @Param address path string true "Wallet Address"
+// @Param request body models.UpdateWatchedAddressReq true "Watched address"
+// @Success 200 {object} models.WatchedAddress
+// @Failure 400 {object} models.ErrorResp
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/watchlist/{network}/{address} [put]
+func (s *Server) UpdateWatchedAddressHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	var req models.UpdateWatchedAddressReq
+	err = c.BodyParser(&req)
+	if err != nil {
+		logger.Warn("failed to parse request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+	err = s.Validate.Struct(req)
+	if err != nil {
+		logger.Warn("invalid request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	resp, err := s.Service.UpdateWatchedAddress(ctx, c.Params("network"), c.Params("address"), req)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
+
+// @Description Remove an address from the watchlist
+// @Tags watchlist
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param network path string true "Network" Enums(ethereum, tron)
+// @Param address path string true "Wallet Address"
+// @Success 204
+// @Failure 400 {object} models.ErrorResp
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/watchlist/{network}/{address} [delete]
+func (s *Server) DeleteWatchedAddressHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	err = s.Service.DeleteWatchedAddress(ctx, c.Params("network"), c.Params("address"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// @Description Get the recorded balance changes of a watched address, newest first
+// @Tags watchlist
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param network path string true "Network" Enums(ethereum, tron)
+// @Param address path string true "Wallet Address"
+// @Success 200 {array} models.BalanceChange
+// @Failure 400 {object} models.ErrorResp
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/watchlist/{network}/{address}/balance-changes [get]
+func (s *Server) ListBalanceChangesHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	resp, err := s.Service.ListBalanceChanges(ctx, c.Params("network"), c.Params("address"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/OwodDEV/crypto-service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Description Subscribe a URL to transfer events of the given addresses.
+// @Description Every event is POSTed as JSON with the headers X-Webhook-Event-ID, X-Webhook-Timestamp
+// @Description and X-Webhook-Signature: "sha256=" + hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the returned secret.
+// @Description Failed deliveries are retried with exponential backoff.
+// @Tags webhooks
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param request body models.CreateWebhookSubscriptionReq true "Subscription"
+// @Success 201 {object} models.WebhookSubscription
+// @Failure 400 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/webhooks [post]
+func (s *Server) CreateWebhookSubscriptionHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+	logger := slog.With(
+		slog.String("request_id", ctx.Value("request_id").(string)),
+	)
+
+	var req models.CreateWebhookSubscriptionReq
+	err = c.BodyParser(&req)
+	if err != nil {
+		logger.Warn("failed to parse request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+	err = s.Validate.Struct(req)
+	if err != nil {
+		logger.Warn("invalid request body", slog.Any("error", err))
+		return sendError(c, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	resp, err := s.Service.CreateWebhookSubscription(ctx, req)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusCreated)
+	return
+}
+
+// @Description Get a webhook subscription
+// @Tags webhooks
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/webhooks/{id} [get]
+func (s *Server) GetWebhookSubscriptionHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	resp, err := s.Service.GetWebhookSubscription(ctx, c.Params("id"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
+
+// @Description Delete a webhook subscription
+// @Tags webhooks
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param id path string true "Subscription ID"
+// @Success 204
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/webhooks/{id} [delete]
+func (s *Server) DeleteWebhookSubscriptionHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	err = s.Service.DeleteWebhookSubscription(ctx, c.Params("id"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// @Description Get the delivery log of a webhook subscription, newest first
+// @Tags webhooks
+// @HeaderParam X-Request-ID string false "Optional request ID for tracing"
+// @Param id path string true "Subscription ID"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 404 {object} models.ErrorResp
+// @Failure 500 {object} models.ErrorResp
+// @Router /api/webhooks/{id}/deliveries [get]
+func (s *Server) ListWebhookDeliveriesHandler(c *fiber.Ctx) (err error) {
+	ctx := c.UserContext()
+
+	resp, err := s.Service.ListWebhookDeliveries(ctx, c.Params("id"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	c.JSON(resp)
+	c.Status(http.StatusOK)
+	return
+}
@@ -1,8 +1,15 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount   = errors.New("invalid amount")
+	ErrTooManyDecimals = errors.New("amount has too many decimal places")
 )
 
 func FormatCurrency(value *big.Int, tokenDecimals int) string {
@@ -12,3 +19,50 @@ func FormatCurrency(value *big.Int, tokenDecimals int) string {
 	format := fmt.Sprintf("%%.%df", tokenDecimals)
 	return fmt.Sprintf(format, valueFloat)
 }
+
+func ParseCurrency(value string, tokenDecimals int) (*big.Int, error) {
+	intPart, fracPart, _ := strings.Cut(value, ".")
+	if len(fracPart) > tokenDecimals {
+		return nil, ErrTooManyDecimals
+	}
+	fracPart += strings.Repeat("0", tokenDecimals-len(fracPart))
+
+	raw, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok || raw.Sign() < 0 {
+		return nil, ErrInvalidAmount
+	}
+	return raw, nil
+}
+
+// CompareCurrency compares two decimal amounts and returns -1, 0 or +1.
+func CompareCurrency(a, b string) (int, error) {
+	aRat, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidAmount, a)
+	}
+	bRat, ok := new(big.Rat).SetString(b)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidAmount, b)
+	}
+	return aRat.Cmp(bRat), nil
+}
+
+// AddCurrency sums two decimal amounts keeping the larger number of decimal places.
+func AddCurrency(a, b string) (string, error) {
+	aRat, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidAmount, a)
+	}
+	bRat, ok := new(big.Rat).SetString(b)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidAmount, b)
+	}
+
+	decimals := max(decimalPlaces(a), decimalPlaces(b))
+	return new(big.Rat).Add(aRat, bRat).FloatString(decimals), nil
+}
+
+func decimalPlaces(value string) int {
+	_, fracPart, _ := strings.Cut(value, ".")
+	return len(fracPart)
+}
@@ -5,6 +5,12 @@ import (
 	"strings"
 )
 
+var (
+	ErrUnknownAddress     = errors.New("unable to detect the network by address")
+	ErrUnknownHash        = errors.New("unable to detect the network by hash")
+	ErrUnknownNetworkName = errors.New("unable to detect the network by name")
+)
+
 func DetectNetworkByAddr(address string) (network string, err error) {
 	if strings.HasPrefix(address, "0x") && len(address) == 42 {
 		return "ERC20", nil
@@ -14,7 +20,7 @@ func DetectNetworkByAddr(address string) (network string, err error) {
 		return "TRC20", nil
 	}
 
-	err = errors.New("unable to detect the network by address")
+	err = ErrUnknownAddress
 	return
 }
 
@@ -27,6 +33,27 @@ func DetectNetworkByHash(hash string) (network string, err error) {
 		return "TRC20", nil
 	}
 
-	err = errors.New("unable to detect the network by hash")
+	err = ErrUnknownHash
+	return
+}
+
+func DetectNetworkByName(name string) (network string, err error) {
+	switch strings.ToLower(name) {
+	case "ethereum", "eth", "erc20":
+		return "ERC20", nil
+	case "tron", "trx", "trc20":
+		return "TRC20", nil
+	}
+
+	err = ErrUnknownNetworkName
 	return
 }
+
+// NormalizeAddress returns the canonical form of an address used for lookups:
+// Ethereum addresses are case-insensitive, Tron base58 addresses are not.
+func NormalizeAddress(network, address string) string {
+	if network == "ERC20" {
+		return strings.ToLower(address)
+	}
+	return address
+}